@@ -41,9 +41,11 @@ import (
 	_ "github.com/viant/endly/testing/log"
 	_ "github.com/viant/endly/testing/validator"
 
+	_ "github.com/viant/endly/testing/endpoint/grpc"
 	_ "github.com/viant/endly/testing/endpoint/http"
 	_ "github.com/viant/endly/testing/endpoint/smtp"
 	_ "github.com/viant/endly/testing/msg"
+	_ "github.com/viant/endly/testing/runner/grpc"
 	_ "github.com/viant/endly/testing/runner/http"
 	_ "github.com/viant/endly/testing/runner/rest"
 	_ "github.com/viant/endly/testing/runner/selenium"
@@ -112,6 +114,7 @@ import (
 	"github.com/viant/endly/gen/web"
 	"github.com/viant/endly/meta"
 	"github.com/viant/endly/model"
+	"github.com/viant/endly/server"
 	"github.com/viant/endly/workflow"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/cred"
@@ -167,6 +170,16 @@ func init() {
 	flag.Int("e", 5, "max number of failures CLI reported per validation, 0 - all failures reported")
 	flag.String("run", "", "run specified service action it expect valid service:action to run")
 	flag.String("req", "", "optional request URL when run option is specified")
+	flag.String("cp", "", "<checkpoint URL> persist workflow progress after each completed task")
+	flag.Bool("resume", false, "resume workflow from the first incomplete task recorded with -cp checkpoint")
+	flag.Bool("dry", false, "dry run, print expanded workflow plan without calling any service")
+	flag.Bool("debug", false, "interactive step debugger, pauses before the first action or on -b breakpoints")
+	flag.String("b", "", "<breakpoints> coma separated task, task.action, *.action or #tagID list, works only with -debug option")
+	flag.String("trace", "", "<trace URL> export workflow, task and action OpenTelemetry spans, http(s)://host:port for OTLP/HTTP, file path for JSON lines file")
+	flag.String("o", "", "<output format> jsonl streams every event as JSON line instead of terminal output")
+	flag.String("remote", "", "<endly server URL> run workflow on remote endly server, i.e. http://host:8071, bearer token is read from ENDLY_REMOTE_TOKEN env")
+	flag.Bool("lint", false, "statically validate workflow and print diagnostics, exits with 1 if any error diagnostic found")
+	flag.String("config", "", "<server config URL> start endly server with port, TLS and credentials from YAML or JSON config")
 	_ = mysql.SetLogger(&emptyLogger{})
 
 }
@@ -216,6 +229,11 @@ func Bootstrap() {
 		return
 	}
 
+	if configURL, ok := flagset["config"]; ok {
+		startServer(configURL)
+		return
+	}
+
 	if toolbox.AsBoolean(flagset["v"]) {
 		printVersion()
 		if shouldQuit {
@@ -297,8 +315,12 @@ func Bootstrap() {
 		printWorkflowTasks(request)
 		return
 	}
+	if value, ok := flagset["lint"]; ok && toolbox.AsBoolean(value) {
+		lintWorkflow(request)
+		return
+	}
 	interactive, ok := flagset["m"]
-	runWorkflow(request, ok && toolbox.AsBoolean(interactive))
+	runWorkflow(request, ok && toolbox.AsBoolean(interactive), flagset)
 }
 
 func runAction(run string, flagset map[string]string) error {
@@ -333,12 +355,28 @@ func runAction(run string, flagset map[string]string) error {
 		return nil
 	}
 	interactive, ok := flagset["m"]
-	runWorkflow(request, ok && toolbox.AsBoolean(interactive))
+	runWorkflow(request, ok && toolbox.AsBoolean(interactive), flagset)
 	return nil
 }
 
-func runWorkflow(request *workflow.RunRequest, interactive bool) {
+func runWorkflow(request *workflow.RunRequest, interactive bool, flagset map[string]string) {
 	runner := cli.New()
+	switch output := flagset["o"]; output {
+	case "":
+	case "jsonl":
+		runner.EnableEventStream(os.Stdout)
+	default:
+		log.Fatalf("unsupported output format: %v", output)
+	}
+	if remoteURL := flagset["remote"]; remoteURL != "" {
+		if value, ok := flagset["debug"]; ok && toolbox.AsBoolean(value) {
+			log.Fatalf("debugger is not supported with remote run")
+		}
+		runner.EnableRemote(remoteURL)
+	}
+	if value, ok := flagset["debug"]; ok && toolbox.AsBoolean(value) {
+		runner.EnableDebugger(strings.Split(flag.Lookup("b").Value.String(), ",")...)
+	}
 	request.Interactive = interactive
 	err := runner.Run(request)
 	if err != nil {
@@ -478,6 +516,13 @@ func printWorkflowTasks(request *workflow.RunRequest) {
 	for _, task := range workFlow.Tasks {
 		_, _ = fmt.Fprintf(os.Stderr, "\t%v: %v\n", task.Name, task.Description)
 	}
+	if len(workFlow.Parameters) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "Workflow '%v' parameters:\n", workFlow.Name)
+	for _, parameter := range workFlow.Parameters {
+		_, _ = fmt.Fprintf(os.Stderr, "\t%v\n", parameter.String())
+	}
 }
 
 func requestName(name string, ext string) string {
@@ -658,6 +703,19 @@ func printWorkflow(request *workflow.RunRequest) {
 
 }
 
+func lintWorkflow(request *workflow.RunRequest) {
+	manager := endly.New()
+	context := manager.NewContext(nil)
+	var response = &workflow.LintResponse{}
+	if err := endly.Run(context, &workflow.LintRequest{RunRequest: request}, response); err != nil {
+		log.Fatal(err)
+	}
+	printInFormat(response, "failed to print lint diagnostics: "+request.URL+", %v", false)
+	if response.Errors > 0 {
+		os.Exit(1)
+	}
+}
+
 func printInFormat(source interface{}, errorTemplate string, hideEmpty bool) {
 	if hideEmpty {
 		var aMap = map[string]interface{}{}
@@ -799,6 +857,18 @@ func updateBaseRunWithOptions(request *workflow.RunRequest, flagset map[string]s
 	if value, ok := flagset["e"]; ok {
 		request.FailureCount = toolbox.AsInt(value)
 	}
+	if value, ok := flagset["cp"]; ok {
+		request.CheckpointURL = url.NewResource(value).URL
+	}
+	if value, ok := flagset["resume"]; ok {
+		request.Resume = toolbox.AsBoolean(value)
+	}
+	if value, ok := flagset["dry"]; ok {
+		request.DryRun = toolbox.AsBoolean(value)
+	}
+	if value, ok := flagset["trace"]; ok {
+		request.TraceURL = value
+	}
 	return nil
 }
 
@@ -806,6 +876,20 @@ func startRecorder(URLs []string) {
 	rec.StartRecorder(URLs...)
 }
 
+func startServer(configURL string) {
+	config, err := server.NewConfigFromURL(configURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv, err := server.NewWithConfig(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = srv.Start(); err != nil {
+		log.Fatal(err)
+	}
+}
+
 type emptyLogger struct{}
 
 func (l *emptyLogger) Print(v ...interface{}) {
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/workflow"
+	"github.com/viant/toolbox"
+	"io"
+	"sort"
+	"strings"
+)
+
+const debuggerHelp = `commands:
+  c, continue        resume until next breakpoint
+  n, next            pause before next action, step over nested workflow
+  s, step            pause before next action, step into nested workflow
+  o, out             pause before next action of caller workflow
+  p, print [key]     print state keys or state value (i.e. p params.env)
+  set key=value      set state value, JSON value is decoded (i.e. set target="qa")
+  r, request         print action request expanded with current state
+  b, break [expr]    list or add breakpoint: task, task.action, *.action or #tagID
+  d, delete expr     delete breakpoint
+  q, quit            abort workflow
+`
+
+//Debugger represents terminal workflow debugger
+type Debugger struct {
+	*Renderer
+	*Style
+	*workflow.Debugger
+	reader *bufio.Reader
+}
+
+//Handle prints paused action and reads terminal commands until execution is resumed
+func (d *Debugger) Handle(context *endly.Context, pause *workflow.DebugPause) string {
+	var location = fmt.Sprintf("%v.%v %v:%v", pause.Workflow, pause.Task, pause.Service, pause.Action)
+	var reason = "step"
+	if pause.Breakpoint != "" {
+		reason = "breakpoint " + pause.Breakpoint
+	}
+	d.Printf("%v %v %v\n", d.ColorText("paused", "inverse"), d.ColorText(location, d.ServiceActionColor), d.ColorText("("+pause.TagID+", "+reason+")", d.TagColor))
+	for {
+		d.Print("debug> ")
+		line, err := d.reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if err == io.EOF {
+				return workflow.DebugContinue
+			}
+			continue
+		}
+		var command, argument = line, ""
+		if index := strings.Index(line, " "); index != -1 {
+			command, argument = string(line[:index]), strings.TrimSpace(string(line[index+1:]))
+		}
+		switch command {
+		case "c", "continue":
+			return workflow.DebugContinue
+		case "n", "next":
+			return workflow.DebugStepOver
+		case "s", "step":
+			return workflow.DebugStepInto
+		case "o", "out":
+			return workflow.DebugStepOut
+		case "q", "quit", "abort":
+			return workflow.DebugAbort
+		case "p", "print":
+			d.printState(context, argument)
+		case "set":
+			d.setState(context, argument)
+		case "r", "request":
+			d.printValue(pause.Request, d.InputColor)
+		case "b", "break":
+			d.addBreakpoint(argument)
+		case "d", "delete":
+			d.deleteBreakpoint(argument)
+		case "h", "help", "?":
+			d.Print(debuggerHelp)
+		default:
+			d.Printf("%v\n", d.ColorText("unknown command: "+command+", type h for help", d.Style.ErrorColor))
+		}
+	}
+}
+
+func (d *Debugger) printState(context *endly.Context, key string) {
+	var state = context.State()
+	if key == "" {
+		var keys = toolbox.MapKeysToStringSlice(state)
+		sort.Strings(keys)
+		d.Printf("%v\n", strings.Join(keys, ", "))
+		return
+	}
+	value, has := state.GetValue(key)
+	if !has {
+		d.Printf("%v\n", d.ColorText("undefined: "+key, d.Style.ErrorColor))
+		return
+	}
+	d.printValue(value, d.OutputColor)
+}
+
+func (d *Debugger) setState(context *endly.Context, assignment string) {
+	index := strings.Index(assignment, "=")
+	if index == -1 {
+		d.Printf("%v\n", d.ColorText("expected key=value", d.Style.ErrorColor))
+		return
+	}
+	key := strings.TrimSpace(string(assignment[:index]))
+	text := strings.TrimSpace(string(assignment[index+1:]))
+	var value interface{} = text
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(text), &decoded); err == nil {
+		value = decoded
+	}
+	var state = context.State()
+	state.SetValue(key, value)
+	d.printValue(value, d.OutputColor)
+}
+
+func (d *Debugger) printValue(value interface{}, color string) {
+	text := toolbox.AsString(value)
+	if toolbox.IsMap(value) || toolbox.IsSlice(value) || toolbox.IsStruct(value) {
+		if JSON, err := toolbox.AsIndentJSONText(value); err == nil {
+			text = JSON
+		}
+	}
+	d.Printf("%v\n", d.ColorText(text, color))
+}
+
+func (d *Debugger) addBreakpoint(expression string) {
+	if expression != "" {
+		d.Breakpoints = append(d.Breakpoints, workflow.NewBreakpoint(expression))
+	}
+	for _, breakpoint := range d.Breakpoints {
+		d.Printf("%v\n", breakpoint.String())
+	}
+}
+
+func (d *Debugger) deleteBreakpoint(expression string) {
+	var breakpoints = make([]*workflow.Breakpoint, 0)
+	for _, breakpoint := range d.Breakpoints {
+		if breakpoint.String() != workflow.NewBreakpoint(expression).String() {
+			breakpoints = append(breakpoints, breakpoint)
+		}
+	}
+	d.Breakpoints = breakpoints
+}
+
+//NewDebugger creates a terminal debugger for supplied breakpoint expressions, with no breakpoints it pauses before the first action
+func NewDebugger(reader io.Reader, writer io.Writer, breakpoints ...string) *Debugger {
+	var result = &Debugger{
+		Renderer: NewRenderer(writer, 120),
+		Style:    NewStyle(),
+		reader:   bufio.NewReader(reader),
+	}
+	var points = make([]*workflow.Breakpoint, 0)
+	for _, expression := range breakpoints {
+		if strings.TrimSpace(expression) != "" {
+			points = append(points, workflow.NewBreakpoint(expression))
+		}
+	}
+	result.Debugger = workflow.NewDebugger(result.Handle, points...)
+	return result
+}
@@ -0,0 +1,64 @@
+package cli_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/cli"
+	"github.com/viant/endly/workflow"
+	"strings"
+	"testing"
+)
+
+func TestDebugger_Handle(t *testing.T) {
+	var useCases = []struct {
+		description string
+		input       string
+		expected    string
+		target      interface{}
+		breakpoints int
+	}{
+		{
+			description: "step over after state update",
+			input:       "p target\nset target=\"qa\"\nn\n",
+			expected:    workflow.DebugStepOver,
+			target:      "qa",
+		},
+		{
+			description: "add breakpoint and continue",
+			input:       "b #tag2\nb task1.print\nc\n",
+			expected:    workflow.DebugContinue,
+			target:      "dev",
+			breakpoints: 3,
+		},
+		{
+			description: "abort",
+			input:       "foo\nq\n",
+			expected:    workflow.DebugAbort,
+			target:      "dev",
+			breakpoints: 1,
+		},
+		{
+			description: "continue on end of input",
+			input:       "s",
+			expected:    workflow.DebugStepInto,
+			target:      "dev",
+			breakpoints: 1,
+		},
+	}
+	for _, useCase := range useCases {
+		context := endly.New().NewContext(nil)
+		state := context.State()
+		state.Put("target", "dev")
+		var output = new(bytes.Buffer)
+		debugger := cli.NewDebugger(strings.NewReader(useCase.input), output, "task1")
+		if useCase.breakpoints == 0 {
+			useCase.breakpoints = 1
+		}
+		command := debugger.Handle(context, &workflow.DebugPause{Workflow: "app", Task: "task1", TagID: "tag1", Service: "workflow", Action: "print"})
+		assert.EqualValues(t, useCase.expected, command, useCase.description)
+		assert.EqualValues(t, useCase.target, state.Get("target"), useCase.description)
+		assert.EqualValues(t, useCase.breakpoints, len(debugger.Breakpoints), useCase.description)
+		assert.True(t, strings.Contains(output.String(), "app.task1 workflow:print"), useCase.description)
+	}
+}
@@ -19,6 +19,7 @@ type Event struct {
 	Validation  []*assertly.Validation
 	PassedCount int
 	FailedCount int
+	Matrix      string
 	subEvent    *Event
 }
 
@@ -36,7 +37,9 @@ type Events struct {
 	activity   *model.Activity
 	tags       []*Event
 	indexedTag map[string]*Event
+	cellTags   map[string]map[string]*Event
 	eventTag   *Event
+	matrix     string
 	mutex      *sync.RWMutex
 }
 
@@ -44,10 +47,28 @@ type Events struct {
 func (r *Events) AddTag(event *Event) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	event.Matrix = r.matrix
 	r.tags = append(r.tags, event)
 	r.indexedTag[event.TagID] = event
 }
 
+//SetMatrixCell sets current matrix cell, subsequent tags are grouped by the cell, concurrent cells keep their own tag index
+func (r *Events) SetMatrixCell(cellID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.matrix = cellID
+	if cellID == "" {
+		r.indexedTag = make(map[string]*Event)
+		return
+	}
+	indexedTag, ok := r.cellTags[cellID]
+	if !ok {
+		indexedTag = make(map[string]*Event)
+		r.cellTags[cellID] = indexedTag
+	}
+	r.indexedTag = indexedTag
+}
+
 //Event returns an event tag
 func (r *Events) EventTag() *Event {
 	if r.Len() == 0 {
@@ -130,6 +151,7 @@ func NewEventTags() *Events {
 		Activities: model.NewActivities(),
 		tags:       make([]*Event, 0),
 		indexedTag: make(map[string]*Event),
+		cellTags:   make(map[string]map[string]*Event),
 		mutex:      &sync.RWMutex{},
 	}
 }
@@ -0,0 +1,28 @@
+package cli_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/cli"
+	"github.com/viant/endly/model"
+	"testing"
+)
+
+func TestEvents_SetMatrixCell(t *testing.T) {
+	events := cli.NewEventTags()
+	events.Push(&model.Activity{MetaTag: &model.MetaTag{Tag: "test", TagID: "test_01"}})
+
+	events.SetMatrixCell("db=mysql")
+	mysqlTag := events.EventTag()
+	assert.EqualValues(t, "db=mysql", mysqlTag.Matrix)
+
+	events.SetMatrixCell("db=pg")
+	pgTag := events.EventTag()
+	assert.EqualValues(t, "db=pg", pgTag.Matrix)
+
+	//interleaved cell events keep grouping under the cell tag
+	events.SetMatrixCell("db=mysql")
+	assert.True(t, mysqlTag == events.EventTag())
+
+	events.SetMatrixCell("")
+	assert.EqualValues(t, "", events.EventTag().Matrix)
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/endly/workflow"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	//RemoteTokenEnvKey represents env variable with remote endly server bearer token
+	RemoteTokenEnvKey = "ENDLY_REMOTE_TOKEN"
+	remoteRunURI      = "/v1/endly/service/workflow/run/"
+	remoteResponse    = "response"
+)
+
+//remoteRecord represents streamed remote event record with raw value
+type remoteRecord struct {
+	EventRecord
+	Value json.RawMessage
+}
+
+//remoteRunResponse represents remote service response
+type remoteRunResponse struct {
+	Status string
+	Error  string
+}
+
+//remoteEvents decodes remote event records into events with typed values
+type remoteEvents struct {
+	types   map[string]reflect.Type
+	started map[string]msg.Event
+}
+
+//newValue returns pointer to a new value for supplied event type, registered event types and service request/response types are supported
+func (e *remoteEvents) newValue(typeName string) (interface{}, bool) {
+	if value, ok := msg.NewValue(typeName); ok {
+		return value, true
+	}
+	if valueType, ok := e.types[typeName]; ok {
+		return reflect.New(valueType).Interface(), true
+	}
+	return nil, false
+}
+
+//decode decodes remote event record, activity end event is linked with its start event
+func (e *remoteEvents) decode(data []byte) (msg.Event, error) {
+	var record = &remoteRecord{}
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, err
+	}
+	value, ok := e.newValue(record.Type)
+	if !ok || json.Unmarshal(record.Value, value) != nil {
+		value = nil
+		if err := json.Unmarshal(record.Value, &value); err != nil {
+			return nil, err
+		}
+	}
+	var init msg.Event
+	if _, ended := value.(*model.ActivityEndEvent); ended && record.ActivityID != "" {
+		init = e.started[record.ActivityID]
+		delete(e.started, record.ActivityID)
+	}
+	event := msg.NewEventAt(value, record.Timestamp, init)
+	event.SetLoggable(record.Loggable)
+	if _, started := value.(*model.Activity); started && record.ActivityID != "" {
+		e.started[record.ActivityID] = event
+	}
+	return event, nil
+}
+
+func newRemoteEvents(manager endly.Manager) *remoteEvents {
+	var result = &remoteEvents{
+		types:   make(map[string]reflect.Type),
+		started: make(map[string]msg.Event),
+	}
+	for _, service := range endly.Services(manager) {
+		for _, action := range service.Actions() {
+			route, err := service.Route(action)
+			if err != nil {
+				continue
+			}
+			for _, value := range []interface{}{route.RequestProvider(), route.ResponseProvider()} {
+				valueType := reflect.TypeOf(value)
+				for valueType != nil && valueType.Kind() == reflect.Ptr {
+					valueType = valueType.Elem()
+				}
+				if valueType != nil {
+					result.types[msg.TypeName(value)] = valueType
+				}
+			}
+		}
+	}
+	return result
+}
+
+//readServerSentEvents reads server-sent events calling handler with each event name and data
+func readServerSentEvents(reader io.Reader, handler func(name string, data []byte) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	var name string
+	var data = new(bytes.Buffer)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				if err := handler(name, data.Bytes()); err != nil {
+					return err
+				}
+			}
+			name = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(line[6:])
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(line[5:], " "))
+		}
+	}
+	return scanner.Err()
+}
+
+//runRemote sends run request to remote endly server and renders its streamed events, remote run error is published as error event
+func (r *Runner) runRemote(request *workflow.RunRequest) error {
+	var remoteRequest = *request
+	remoteRequest.Async = false
+	body, err := json.Marshal(map[string]interface{}{"ServiceRequest": &remoteRequest})
+	if err != nil {
+		return err
+	}
+	URL := strings.TrimRight(r.remoteURL, "/") + remoteRunURI
+	httpRequest, err := http.NewRequest("POST", URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "text/event-stream")
+	if token := os.Getenv(RemoteTokenEnvKey); token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+	}
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to run remote workflow: %v", err)
+	}
+	defer httpResponse.Body.Close()
+	if !strings.Contains(httpResponse.Header.Get("Content-Type"), "text/event-stream") {
+		var response = &remoteRunResponse{}
+		if err := json.NewDecoder(httpResponse.Body).Decode(response); err == nil && response.Error != "" {
+			return fmt.Errorf("failed to run remote workflow: %v", response.Error)
+		}
+		return fmt.Errorf("failed to run remote workflow: %v %v", URL, httpResponse.Status)
+	}
+	r.printMessage(r.ColorText(r.remoteURL, r.TagColor), msg.MessageStyleGeneric, fmt.Sprintf("%v", time.Now()), msg.MessageStyleGeneric, "started")
+	var events = newRemoteEvents(r.manager)
+	var response *remoteRunResponse
+	err = readServerSentEvents(httpResponse.Body, func(name string, data []byte) error {
+		if name == remoteResponse {
+			response = &remoteRunResponse{}
+			return json.Unmarshal(data, response)
+		}
+		event, err := events.decode(data)
+		if err != nil {
+			return fmt.Errorf("failed to decode remote event: %v", err)
+		}
+		r.context.Listener(event)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if response == nil {
+		return fmt.Errorf("remote workflow stream ended without response: %v", URL)
+	}
+	if response.Error != "" {
+		r.context.Listener(msg.NewEvent(msg.NewErrorEvent(response.Error)))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/cli"
+	"github.com/viant/endly/server"
+	"github.com/viant/endly/workflow"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunner_EnableRemote(t *testing.T) {
+	go server.New("8437").Start()
+	time.Sleep(500 * time.Millisecond)
+	var exitCode = 0
+	origin := cli.OnError
+	defer func() {
+		cli.OnError = origin
+	}()
+	cli.OnError = func(code int) {
+		exitCode = code
+	}
+
+	{ //successful remote run is rendered as local
+		var output = new(bytes.Buffer)
+		runner := cli.New()
+		runner.EnableRemote("http://127.0.0.1:8437/")
+		runner.Renderer = cli.NewRenderer(output, 120)
+		err := runner.Run(&workflow.RunRequest{URL: "../workflow/test/nop/workflow.csv", Name: "nop", Tasks: "*"})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, exitCode)
+		assert.Equal(t, 3, strings.Count(output.String(), "nop.nop"), output.String())
+	}
+
+	{ //failed remote run exits with error, streamed activities are decoded
+		var output = new(bytes.Buffer)
+		runner := cli.New()
+		runner.EnableRemote("http://127.0.0.1:8437")
+		runner.EnableEventStream(output)
+		err := runner.Run(&workflow.RunRequest{URL: "../workflow/test/tracing/workflow.csv", Name: "tracing", Tasks: "*"})
+		if assert.NotNil(t, err) {
+			assert.True(t, strings.Contains(err.Error(), "upload failed"), err.Error())
+		}
+		assert.Equal(t, 1, exitCode)
+		var actions = make([]string, 0)
+		scanner := bufio.NewScanner(output)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			record := &cli.EventRecord{}
+			assert.Nil(t, json.Unmarshal(scanner.Bytes(), record))
+			if record.Type == "model_ActivityEndEvent" {
+				actions = append(actions, record.Service+":"+record.Action)
+			}
+		}
+		assert.Equal(t, []string{"workflow:print", "workflow:fail"}, actions)
+	}
+
+	{ //unreachable server
+		exitCode = 0
+		runner := cli.New()
+		runner.EnableRemote("http://127.0.0.1:1")
+		runner.Renderer = cli.NewRenderer(new(bytes.Buffer), 120)
+		err := runner.Run(&workflow.RunRequest{URL: "../workflow/test/nop/workflow.csv", Name: "nop"})
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, exitCode)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
 	"gopkg.in/yaml.v2"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -59,6 +60,10 @@ type Runner struct {
 	hasValidationFailures bool
 	err                   error
 	group                 *MessageGroup
+	matrixCells           []*workflow.MatrixCellEndEvent
+	debugger              *Debugger
+	stream                *EventStream
+	remoteURL             string
 }
 
 func (r *Runner) printInput(output string) {
@@ -308,6 +313,7 @@ func (r *Runner) processEvent(event msg.Event, filter map[string]bool) {
 		return
 	}
 	r.processActivityEnd(event)
+	r.processMatrixCell(event)
 	if r.processActivityStart(event) {
 		return
 	}
@@ -327,6 +333,16 @@ func (r *Runner) processEvent(event msg.Event, filter map[string]bool) {
 
 }
 
+func (r *Runner) processMatrixCell(event msg.Event) {
+	switch value := event.Value().(type) {
+	case *workflow.MatrixCellEvent:
+		r.SetMatrixCell(value.ID)
+	case *workflow.MatrixCellEndEvent:
+		r.SetMatrixCell("")
+		r.matrixCells = append(r.matrixCells, value)
+	}
+}
+
 type runnerLog struct {
 	In         msg.Event
 	Out        msg.Event
@@ -456,9 +472,35 @@ func (r *Runner) reportSummaryEvent() {
 	if totalTagValidated == 0 {
 		validationInfo = ""
 	}
+	r.reportMatrixSummary()
 	r.printMessage(contextMessage, msg.MessageStyleGeneric, validationInfo, msg.MessageStyleGeneric, fmt.Sprintf("elapsed: %v ms", r.report.ElapsedMs))
 }
 
+func (r *Runner) reportMatrixSummary() {
+	for _, cell := range r.matrixCells {
+		var passed, failed = 0, 0
+		for _, tag := range r.tags {
+			if tag.Matrix != cell.ID {
+				continue
+			}
+			if tag.FailedCount > 0 {
+				failed++
+			} else if tag.PassedCount > 0 {
+				passed++
+			}
+		}
+		var status, color, messageStyle = "SUCCESS", "green", msg.MessageStyleSuccess
+		if cell.Error != "" || failed > 0 {
+			status, color, messageStyle = "FAILED", "red", msg.MessageStyleError
+		}
+		var validationInfo = ""
+		if passed+failed > 0 {
+			validationInfo = fmt.Sprintf("Passed %v/%v (TagIDs).", passed, passed+failed)
+		}
+		r.printMessage(fmt.Sprintf("MATRIX %v: %v", cell.ID, r.ColorText(status, color)), msg.MessageStyleGeneric, validationInfo, messageStyle, "matrix")
+	}
+}
+
 func (r *Runner) getValidation(event msg.Event) *assertly.Validation {
 	var eventValue = event.Value()
 	validation, ok := eventValue.(*assertly.Validation)
@@ -510,7 +552,17 @@ func (r *Runner) reportTagSummary() {
 			continue
 		}
 		useCase := xunit.NewTestCase()
-		r.xUnitSummary.TestCase = append(r.xUnitSummary.TestCase, useCase)
+		if tag.Matrix != "" {
+			suite := r.xUnitSummary.Suite(tag.Matrix)
+			suite.TestCase = append(suite.TestCase, useCase)
+			suite.Tests = fmt.Sprintf("%d", toolbox.AsInt(suite.Tests)+1)
+			if tag.FailedCount > 0 {
+				suite.Failures = fmt.Sprintf("%d", toolbox.AsInt(suite.Failures)+1)
+			}
+			suite.TestCases = fmt.Sprintf("%d", len(suite.TestCase))
+		} else {
+			r.xUnitSummary.TestCase = append(r.xUnitSummary.TestCase, useCase)
+		}
 		useCase.Label = tag.TagID
 		description := strings.Split(tag.Description, "\n")[0]
 		if description == "" {
@@ -603,6 +655,9 @@ func (r *Runner) AsListener() msg.Listener {
 			r.report.ElapsedMs = int(lastEvent.Timestamp().UnixNano()-firstEvent.Timestamp().UnixNano()) / int(time.Millisecond)
 		}
 		_ = r.reportEvent(r.context, event, r.filter)
+		if r.stream != nil {
+			r.stream.OnEvent(event)
+		}
 	}
 }
 
@@ -631,6 +686,9 @@ func (r *Runner) onCallerStart() {
 func (r *Runner) onCallerEnd() {
 	r.processEventTags()
 	r.reportSummaryEvent()
+	if r.stream != nil {
+		r.stream.OnEvent(msg.NewEvent(r.report))
+	}
 	r.printSummary()
 }
 
@@ -662,14 +720,35 @@ func (r *Runner) printSummary() {
 
 }
 
+//EnableDebugger enables terminal step debugger pausing on supplied breakpoints, or before the first action if none
+func (r *Runner) EnableDebugger(breakpoints ...string) {
+	r.debugger = NewDebugger(os.Stdin, os.Stdout, breakpoints...)
+}
+
+//EnableEventStream streams every event as JSON line to supplied writer instead of rendering terminal output
+func (r *Runner) EnableEventStream(writer io.Writer) {
+	r.stream = NewEventStream(writer)
+	r.Renderer = NewRenderer(ioutil.Discard, 120)
+}
+
+//EnableRemote runs workflow on remote endly server instead of local execution, streamed remote events are rendered as local ones
+func (r *Runner) EnableRemote(URL string) {
+	r.remoteURL = URL
+}
+
 //Run run Caller for the supplied run request and runner options.
 func (r *Runner) Run(request *workflow.RunRequest) (err error) {
 	r.request = request
 	r.context = r.manager.NewContext(toolbox.NewContext())
-	//init shared session
-	exec.TerminalSessions(r.context)
-	exec.SetDefaultTarget(r.context, nil)
-	selenium.Sessions(r.context)
+	if r.remoteURL == "" {
+		//init shared session
+		exec.TerminalSessions(r.context)
+		exec.SetDefaultTarget(r.context, nil)
+		selenium.Sessions(r.context)
+	}
+	if r.debugger != nil {
+		workflow.SetDebugger(r.context, r.debugger.Debugger)
+	}
 
 	r.report = &ReportSummaryEvent{}
 	r.context.CLIEnabled = true
@@ -690,6 +769,12 @@ func (r *Runner) Run(request *workflow.RunRequest) (err error) {
 		}
 	}()
 	r.context.SetListener(r.AsListener())
+	if r.remoteURL != "" {
+		if err = r.runRemote(request); err != nil {
+			r.context.Publish(msg.NewErrorEvent(err.Error()))
+		}
+		return err
+	}
 	request.Async = true
 	var response = &workflow.RunResponse{}
 	err = endly.Run(r.context, request, response)
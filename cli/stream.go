@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"io"
+	"sync"
+	"time"
+)
+
+//EventRecord represents a streamed event JSON line
+type EventRecord struct {
+	Timestamp  time.Time
+	Type       string
+	Package    string
+	ActivityID string      `json:",omitempty"`
+	ParentID   string      `json:",omitempty"`
+	TagID      string      `json:",omitempty"`
+	Service    string      `json:",omitempty"`
+	Action     string      `json:",omitempty"`
+	DurationMs int         `json:",omitempty"`
+	Loggable   bool        `json:",omitempty"`
+	Value      interface{} `json:",omitempty"`
+}
+
+//streamActivity represents open activity
+type streamActivity struct {
+	id       string
+	parentID string
+	*model.Activity
+}
+
+//EventStream represents event stream writing each event as one JSON object per line
+type EventStream struct {
+	writer     io.Writer
+	sequence   int
+	activities []*streamActivity
+	started    map[msg.Event]*streamActivity
+	mux        sync.Mutex
+}
+
+func (s *EventStream) last() *streamActivity {
+	if len(s.activities) == 0 {
+		return nil
+	}
+	return s.activities[len(s.activities)-1]
+}
+
+func (s *EventStream) remove(activity *streamActivity) {
+	for i := len(s.activities) - 1; i >= 0; i-- {
+		if s.activities[i] == activity {
+			s.activities = append(s.activities[:i], s.activities[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *EventStream) newRecord(event msg.Event) *EventRecord {
+	var record = &EventRecord{
+		Timestamp: event.Timestamp(),
+		Type:      event.Type(),
+		Package:   event.Package(),
+		Value:     event.Value(),
+		Loggable:  event.IsLoggable(),
+	}
+	switch value := event.Value().(type) {
+	case *model.Activity:
+		s.sequence++
+		var activity = &streamActivity{id: fmt.Sprintf("%d", s.sequence), Activity: value}
+		if parent := s.last(); parent != nil {
+			activity.parentID = parent.id
+		}
+		s.activities = append(s.activities, activity)
+		s.started[event] = activity
+		record.ActivityID = activity.id
+		record.ParentID = activity.parentID
+		record.TagID = value.TagID
+		record.Service = value.Service
+		record.Action = value.Action
+		return record
+	case *model.ActivityEndEvent:
+		if start := event.Init(); start != nil {
+			if activity, ok := s.started[start]; ok {
+				delete(s.started, start)
+				s.remove(activity)
+				record.ActivityID = activity.id
+				record.ParentID = activity.parentID
+				record.TagID = activity.TagID
+				record.Service = activity.Service
+				record.Action = activity.Action
+				record.DurationMs = int(event.Timestamp().Sub(start.Timestamp()) / time.Millisecond)
+				return record
+			}
+		}
+	}
+	if activity := s.last(); activity != nil {
+		record.ActivityID = activity.id
+		record.ParentID = activity.parentID
+		record.TagID = activity.TagID
+	}
+	return record
+}
+
+//OnEvent writes supplied event as JSON line
+func (s *EventStream) OnEvent(event msg.Event) {
+	if event == nil || event.Value() == nil {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	record := s.newRecord(event)
+	line, err := json.Marshal(record)
+	if err != nil {
+		record.Value = fmt.Sprintf("%v", record.Value)
+		if line, err = json.Marshal(record); err != nil {
+			return
+		}
+	}
+	_, _ = s.writer.Write(append(line, '\n'))
+}
+
+//NewEventStream creates a new JSON lines event stream
+func NewEventStream(writer io.Writer) *EventStream {
+	return &EventStream{
+		writer:     writer,
+		activities: make([]*streamActivity, 0),
+		started:    make(map[msg.Event]*streamActivity),
+	}
+}
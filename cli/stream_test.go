@@ -0,0 +1,61 @@
+package cli_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/cli"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"testing"
+	"time"
+)
+
+func TestEventStream_OnEvent(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	stream := cli.NewEventStream(buf)
+
+	runStart := msg.NewEvent(&model.Activity{Service: "workflow", Action: "run", MetaTag: &model.MetaTag{TagID: "app"}})
+	stream.OnEvent(runStart)
+	execStart := msg.NewEvent(&model.Activity{Service: "exec", Action: "run", MetaTag: &model.MetaTag{TagID: "app_build"}})
+	stream.OnEvent(execStart)
+	stream.OnEvent(msg.NewEvent(msg.NewErrorEvent("failed to build")))
+	time.Sleep(2 * time.Millisecond)
+	stream.OnEvent(msg.NewEventWithInit(model.NewActivityEndEvent(nil), execStart))
+	stream.OnEvent(msg.NewEventWithInit(model.NewActivityEndEvent(nil), runStart))
+
+	var records = make([]*cli.EventRecord, 0)
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		record := &cli.EventRecord{}
+		if !assert.Nil(t, json.Unmarshal(scanner.Bytes(), record)) {
+			return
+		}
+		records = append(records, record)
+	}
+	if !assert.EqualValues(t, 5, len(records)) {
+		return
+	}
+	var expected = []struct {
+		Type       string
+		ActivityID string
+		ParentID   string
+		TagID      string
+	}{
+		{"model_Activity", "1", "", "app"},
+		{"model_Activity", "2", "1", "app_build"},
+		{"msg_ErrorEvent", "2", "1", "app_build"},
+		{"model_ActivityEndEvent", "2", "1", "app_build"},
+		{"model_ActivityEndEvent", "1", "", "app"},
+	}
+	for i, record := range records {
+		assert.EqualValues(t, expected[i].Type, record.Type)
+		assert.EqualValues(t, expected[i].ActivityID, record.ActivityID)
+		assert.EqualValues(t, expected[i].ParentID, record.ParentID)
+		assert.EqualValues(t, expected[i].TagID, record.TagID)
+		assert.False(t, record.Timestamp.IsZero())
+	}
+	assert.EqualValues(t, "exec", records[3].Service)
+	assert.True(t, records[3].DurationMs >= 2)
+}
@@ -14,8 +14,22 @@ type Testsuite struct {
 	TestCases string `xml:"test-cases,attr,omitempty" yaml:"test-cases,omitempty"  json:"test-cases,omitempty" `
 	Reports   string `xml:"reports,attr" yaml:"reports,omitempty"  json:"reports,omitempty" `
 
-	Time     string      `xml:"time,attr,omitempty" yaml:"time,omitempty"  json:"time,omitempty" `
-	TestCase []*TestCase `xml:"testcase" yaml:"test-case,omitempty"  json:"test-case,omitempty" `
+	Time      string       `xml:"time,attr,omitempty" yaml:"time,omitempty"  json:"time,omitempty" `
+	TestCase  []*TestCase  `xml:"testcase" yaml:"test-case,omitempty"  json:"test-case,omitempty" `
+	Testsuite []*Testsuite `xml:"test-suite,omitempty" yaml:"test-suite,omitempty"  json:"test-suite,omitempty" `
+}
+
+//Suite returns child test suite with supplied name, it creates one if needed
+func (s *Testsuite) Suite(name string) *Testsuite {
+	for _, candidate := range s.Testsuite {
+		if candidate.Name == name {
+			return candidate
+		}
+	}
+	result := NewTestsuite()
+	result.Name = name
+	s.Testsuite = append(s.Testsuite, result)
+	return result
 }
 
 func NewTestsuite() *Testsuite {
@@ -27,6 +27,10 @@ const EndlyPanic = "ENDLY_PANIC"
 
 var serviceManagerKey = (*manager)(nil)
 var deferFunctionsKey = (*[]func())(nil)
+var actionAuthorizerKey = (*ActionAuthorizer)(nil)
+
+//ActionAuthorizer returns an error if service action is not allowed to run
+type ActionAuthorizer func(service, action string) error
 
 //Context represents a workflow session context/state
 type Context struct {
@@ -42,24 +46,73 @@ type Context struct {
 	state           data.Map
 	Logging         *bool
 	toolbox.Context
-	cloned []*Context
-	closed int32
+	cloned   []*Context
+	redactor *Redactor
+	closed   int32
+	mux      sync.RWMutex
 }
 
 func (c *Context) Background() context.Context {
-	if c.background != nil {
-		return c.background
+	c.mux.RLock()
+	background := c.background
+	c.mux.RUnlock()
+	if background != nil {
+		return background
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.background == nil {
+		c.background = context.Background()
 	}
-	c.background = context.Background()
 	return c.background
 }
 
-//Publish publishes event to listeners, it updates current run details like activity workflow name etc ...
+//WithTimeout sets background context deadline, returned function cancels it and restores previous background context
+func (c *Context) WithTimeout(timeout time.Duration) func() {
+	background, cancel := context.WithTimeout(c.Background(), timeout)
+	restore := c.WithBackground(background)
+	return func() {
+		cancel()
+		restore()
+	}
+}
+
+//WithBackground replaces context background, returned function restores the previous one
+func (c *Context) WithBackground(background context.Context) func() {
+	parent := c.Background()
+	c.mux.Lock()
+	c.background = background
+	c.mux.Unlock()
+	return func() {
+		c.mux.Lock()
+		c.background = parent
+		c.mux.Unlock()
+	}
+}
+
+//Redactor returns context secret redactor, it is shared by cloned contexts
+func (c *Context) Redactor() *Redactor {
+	c.mux.RLock()
+	redactor := c.redactor
+	c.mux.RUnlock()
+	if redactor != nil {
+		return redactor
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.redactor == nil {
+		c.redactor = NewRedactor()
+	}
+	return c.redactor
+}
+
+//Publish publishes event to listeners, it updates current run details like activity workflow name etc ..., revealed secrets are masked in published event
 func (c *Context) Publish(value interface{}) msg.Event {
 	event, ok := value.(msg.Event)
 	if !ok {
 		event = msg.NewEvent(value)
 	}
+	event = c.Redactor().RedactEvent(event)
 	event.SetLoggable(c.IsLoggingEnabled())
 	if c.Listener != nil {
 		c.Listener(event)
@@ -69,7 +122,7 @@ func (c *Context) Publish(value interface{}) msg.Event {
 
 //PublishWithStartEvent publishes event to listeners, it updates current run details like activity workflow name etc ...
 func (c *Context) PublishWithStartEvent(value interface{}, init msg.Event) msg.Event {
-	event := msg.NewEventWithInit(value, init)
+	event := c.Redactor().RedactEvent(msg.NewEventWithInit(value, init))
 	event.SetLoggable(true)
 	if c.Listener != nil {
 		c.Listener(event)
@@ -95,12 +148,14 @@ func (c *Context) Clone() *Context {
 	result := &Context{}
 	result.Wait = &sync.WaitGroup{}
 	result.Context = c.Context.Clone()
+	result.background = c.Background()
 	result.state = NewDefaultState(c)
 	result.state.Apply(c.state)
 	result.SessionID = c.SessionID
 	result.Listener = c.Listener
 	result.CLIEnabled = c.CLIEnabled
 	result.Secrets = c.Secrets
+	result.redactor = c.Redactor()
 	result.AsyncUnsafeKeys = make(map[interface{}]bool)
 	for k, v := range c.AsyncUnsafeKeys {
 		result.AsyncUnsafeKeys[k] = v
@@ -204,6 +259,20 @@ func (c *Context) Deffer(functions ...func()) []func() {
 	return *result
 }
 
+//SetActionAuthorizer sets authorizer checked before every service action run with this context or its clones, including actions run by workflows
+func (c *Context) SetActionAuthorizer(authorizer ActionAuthorizer) {
+	_ = c.Replace(actionAuthorizerKey, &authorizer)
+}
+
+//AuthorizeAction returns an error if context action authorizer does not allow supplied service action
+func (c *Context) AuthorizeAction(service, action string) error {
+	var authorizer *ActionAuthorizer
+	if c.Context == nil || !c.GetInto(actionAuthorizerKey, &authorizer) {
+		return nil
+	}
+	return (*authorizer)(service, action)
+}
+
 //State returns a context state map.
 func (c *Context) State() data.Map {
 	if c.state == nil {
@@ -447,6 +516,7 @@ func NewDefaultState(ctx *Context) data.Map {
 			}
 			config, err := ctx.Secrets.GetCredentials(key)
 			if err == nil {
+				ctx.Redactor().AddSecretFields(config)
 				var result = make(map[string]interface{})
 				if err = toolbox.DefaultConverter.AssignConverted(&result, config); err == nil {
 
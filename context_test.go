@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNewDefaultState(t *testing.T) {
@@ -106,3 +107,14 @@ func TestContext_Clone(t *testing.T) {
 	}
 
 }
+
+func TestContext_WithTimeout(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	restore := context.WithTimeout(10 * time.Millisecond)
+	cloned := context.Clone()
+	<-cloned.Background().Done()
+	assert.True(t, endly.IsTimeoutError(context.Background().Err()))
+	restore()
+	assert.Nil(t, context.Background().Err())
+}
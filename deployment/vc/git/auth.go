@@ -12,6 +12,7 @@ func getAuth(context *endly.Context, credentials string) (transport.AuthMethod,
 	if err != nil {
 		return nil, err
 	}
+	context.Redactor().AddSecretFields(credConifg)
 	if credConifg.PrivateKeyPath != "" {
 		sshAuth, err := ssh2.NewPublicKeysFromFile("git", credConifg.PrivateKeyPath, credConifg.Password)
 		if err != nil {
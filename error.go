@@ -1,8 +1,11 @@
 package endly
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 //Error represents an workflow execution error
@@ -22,6 +25,16 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("%v at %v ", e.error, strings.Join(e.Path, "/"))
 }
 
+//Unwrap returns underlying error
+func (e *Error) Unwrap() error {
+	return e.error
+}
+
+//IsTimeout returns true if underlying error is caused by timeout
+func (e *Error) IsTimeout() bool {
+	return IsTimeoutError(e.error)
+}
+
 //NewError returns new workflow exception or update path
 func NewError(service, action string, err error) error {
 	if abstractException, ok := err.(*Error); ok {
@@ -33,3 +46,31 @@ func NewError(service, action string, err error) error {
 		error: err,
 	}
 }
+
+//TimeoutError represents workflow node execution timeout error
+type TimeoutError struct {
+	Node    string
+	Timeout time.Duration
+}
+
+//Error returns en error
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%v timed out after %v", e.Node, e.Timeout)
+}
+
+//NewTimeoutError returns new timeout error
+func NewTimeoutError(node string, timeout time.Duration) error {
+	return &TimeoutError{Node: node, Timeout: timeout}
+}
+
+//IsTimeoutError returns true if supplied error or any wrapped error is a timeout error or context deadline exceeded
+func IsTimeoutError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var timeoutError *TimeoutError
+	if errors.As(err, &timeoutError) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
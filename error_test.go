@@ -1,9 +1,11 @@
 package endly
 
 import (
+	"context"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestError_Error(t *testing.T) {
@@ -12,3 +14,16 @@ func TestError_Error(t *testing.T) {
 	var e1 = NewError("s2", "a2", e)
 	assert.Equal(t, "test error 1 at s2.a2/s1.a1 ", e1.Error())
 }
+
+func TestIsTimeoutError(t *testing.T) {
+	var timeoutErr = NewTimeoutError("action a1", time.Second)
+	assert.Equal(t, "action a1 timed out after 1s", timeoutErr.Error())
+	assert.True(t, IsTimeoutError(timeoutErr))
+	var e = NewError("s1", "a1", timeoutErr)
+	assert.True(t, IsTimeoutError(e))
+	assert.True(t, e.(*Error).IsTimeout())
+	assert.True(t, IsTimeoutError(fmt.Errorf("task1: %w", e)))
+	assert.True(t, IsTimeoutError(NewError("s1", "a1", context.DeadlineExceeded)))
+	assert.False(t, IsTimeoutError(NewError("s1", "a1", fmt.Errorf("test error 1"))))
+	assert.False(t, IsTimeoutError(nil))
+}
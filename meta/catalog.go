@@ -0,0 +1,73 @@
+package meta
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"sort"
+)
+
+//ActionFilter returns true if service action should be included
+type ActionFilter func(service, action string) bool
+
+//ServiceEntry represents service catalog entry
+type ServiceEntry struct {
+	ID      string
+	Actions []*ActionEntry
+}
+
+//ActionEntry represents service action catalog entry
+type ActionEntry struct {
+	Action      string
+	Description string `json:",omitempty"`
+	Request     string `description:"request type"`
+	Response    string `description:"response type"`
+}
+
+//routes returns sorted services and their routes matching supplied filter
+func (m *Service) routes(filter ActionFilter) ([]endly.Service, map[string][]*endly.Route) {
+	var services = make([]endly.Service, 0)
+	var routes = make(map[string][]*endly.Route)
+	for _, service := range endly.Services(m.Manager) {
+		var actions = append([]string{}, service.Actions()...)
+		sort.Strings(actions)
+		for _, action := range actions {
+			if filter != nil && !filter(service.ID(), action) {
+				continue
+			}
+			route, err := service.Route(action)
+			if err != nil {
+				continue
+			}
+			routes[service.ID()] = append(routes[service.ID()], route)
+		}
+		if len(routes[service.ID()]) > 0 {
+			services = append(services, service)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID() < services[j].ID()
+	})
+	return services, routes
+}
+
+//Catalog returns registered services with their actions matching supplied filter, nil filter matches all actions
+func (m *Service) Catalog(filter ActionFilter) []*ServiceEntry {
+	services, routes := m.routes(filter)
+	var result = make([]*ServiceEntry, 0)
+	for _, service := range services {
+		var entry = &ServiceEntry{ID: service.ID(), Actions: make([]*ActionEntry, 0)}
+		for _, route := range routes[service.ID()] {
+			var action = &ActionEntry{
+				Action:   route.Action,
+				Request:  fmt.Sprintf("%T", route.RequestProvider()),
+				Response: fmt.Sprintf("%T", route.ResponseProvider()),
+			}
+			if route.RequestInfo != nil {
+				action.Description = route.RequestInfo.Description
+			}
+			entry.Actions = append(entry.Actions, action)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
@@ -0,0 +1,299 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+//OpenAPIVersion represents generated OpenAPI document version
+const OpenAPIVersion = "3.0.3"
+
+//ServiceURIPrefix represents server service action URI prefix
+const ServiceURIPrefix = "/v1/endly/service/"
+
+var timeType = reflect.TypeOf(time.Time{})
+var invalidSchemaNameChars = regexp.MustCompile("[^a-zA-Z0-9._-]")
+
+//OpenAPI represents OpenAPI 3 document
+type OpenAPI struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       *OpenAPIInfo         `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+//OpenAPIInfo represents OpenAPI document info
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+//PathItem represents OpenAPI path operations
+type PathItem struct {
+	Post *Operation `json:"post,omitempty"`
+}
+
+//Operation represents OpenAPI operation
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+//RequestBody represents OpenAPI request body
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+//Response represents OpenAPI response
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+//MediaType represents OpenAPI media type
+type MediaType struct {
+	Schema   *Schema             `json:"schema"`
+	Examples map[string]*Example `json:"examples,omitempty"`
+}
+
+//Example represents OpenAPI example
+type Example struct {
+	Summary string      `json:"summary,omitempty"`
+	Value   interface{} `json:"value"`
+}
+
+//Components represents OpenAPI components
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+//Schema represents OpenAPI schema
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+//schemaBuilder builds schemas for Go types, named struct types are registered as components
+type schemaBuilder struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+//schemaName returns unique component name for supplied named type, i.e. workflow.RunRequest
+func (b *schemaBuilder) schemaName(aType reflect.Type) string {
+	name := invalidSchemaNameChars.ReplaceAllString(aType.String(), "_")
+	candidate := name
+	for index := 1; ; index++ {
+		if _, taken := b.schemas[candidate]; !taken {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%v%d", name, index)
+	}
+}
+
+//Schema returns schema for supplied type
+func (b *schemaBuilder) Schema(aType reflect.Type) *Schema {
+	for aType.Kind() == reflect.Ptr {
+		aType = aType.Elem()
+	}
+	if aType == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch aType.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if aType.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: b.Schema(aType.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.Schema(aType.Elem())}
+	case reflect.Struct:
+		if aType.Name() == "" {
+			return b.structSchema(aType)
+		}
+		name, ok := b.names[aType]
+		if !ok {
+			name = b.schemaName(aType)
+			b.names[aType] = name
+			b.schemas[name] = &Schema{Type: "object"}
+			*b.schemas[name] = *b.structSchema(aType)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{}
+}
+
+//structSchema returns struct object schema, embedded struct fields are inlined
+func (b *schemaBuilder) structSchema(aType reflect.Type) *Schema {
+	var result = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.addFields(result, aType)
+	if len(result.Properties) == 0 {
+		result.Properties = nil
+	}
+	return result
+}
+
+func (b *schemaBuilder) addFields(schema *Schema, aType reflect.Type) {
+	for i := 0; i < aType.NumField(); i++ {
+		field := aType.Field(i)
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			b.addFields(schema, fieldType)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldSchema := b.Schema(field.Type)
+		description, defaultValue := field.Tag.Get("description"), field.Tag.Get("default")
+		if fieldSchema.Ref != "" && (description != "" || defaultValue != "") { //$ref siblings are ignored, so that reference is wrapped
+			fieldSchema = &Schema{AllOf: []*Schema{fieldSchema}}
+		}
+		fieldSchema.Description = description
+		if defaultValue != "" {
+			fieldSchema.Default = defaultValue
+			if fieldSchema.Type == "boolean" || fieldSchema.Type == "integer" || fieldSchema.Type == "number" {
+				var value interface{}
+				if err := json.Unmarshal([]byte(defaultValue), &value); err == nil {
+					fieldSchema.Default = value
+				}
+			}
+		}
+		if toolbox.AsBoolean(field.Tag.Get("required")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = fieldSchema
+	}
+}
+
+//examples returns OpenAPI examples for action info JSON use cases
+func examples(info *endly.ActionInfo) map[string]*Example {
+	if info == nil || len(info.Examples) == 0 {
+		return nil
+	}
+	var result = make(map[string]*Example)
+	for i, useCase := range info.Examples {
+		var value interface{}
+		if err := json.Unmarshal([]byte(useCase.Data), &value); err != nil {
+			continue
+		}
+		result[fmt.Sprintf("example%d", i+1)] = &Example{Summary: useCase.Description, Value: value}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func jsonContent(schema *Schema, examples map[string]*Example) map[string]*MediaType {
+	return map[string]*MediaType{"application/json": {Schema: schema, Examples: examples}}
+}
+
+//operationID returns operation id for service action, i.e. http_runner_send
+func operationID(service, action string) string {
+	return invalidSchemaNameChars.ReplaceAllString(strings.Replace(service, "/", "_", -1)+"_"+action, "_")
+}
+
+//OpenAPI returns OpenAPI document for registered service action routes matching supplied filter, nil filter matches all actions
+func (m *Service) OpenAPI(filter ActionFilter) *OpenAPI {
+	var builder = &schemaBuilder{schemas: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+	var result = &OpenAPI{
+		OpenAPI:    OpenAPIVersion,
+		Info:       &OpenAPIInfo{Title: endly.AppName, Version: endly.GetVersion()},
+		Paths:      make(map[string]*PathItem),
+		Components: &Components{Schemas: builder.schemas},
+	}
+	if result.Info.Version == "" {
+		result.Info.Version = "dev"
+	}
+	dataSchema := &Schema{Type: "object", Description: "context state", AdditionalProperties: &Schema{}}
+	services, routes := m.routes(filter)
+	for _, service := range services {
+		for _, route := range routes[service.ID()] {
+			var operation = &Operation{
+				OperationID: operationID(service.ID(), route.Action),
+				Tags:        []string{service.ID()},
+				RequestBody: &RequestBody{
+					Required: true,
+					Content: jsonContent(&Schema{
+						Type: "object",
+						Properties: map[string]*Schema{
+							"Data":           dataSchema,
+							"ServiceRequest": builder.Schema(reflect.TypeOf(route.RequestProvider())),
+						},
+					}, nil),
+				},
+				Responses: map[string]*Response{
+					"200": {
+						Description: service.ID() + ":" + route.Action + " response",
+						Content: jsonContent(&Schema{
+							Type: "object",
+							Properties: map[string]*Schema{
+								"Status":   {Type: "string"},
+								"Error":    {Type: "string"},
+								"Response": builder.Schema(reflect.TypeOf(route.ResponseProvider())),
+								"Data":     dataSchema,
+							},
+						}, nil),
+					},
+				},
+			}
+			if route.RequestInfo != nil {
+				operation.Summary = route.RequestInfo.Description
+				if requestExamples := examples(route.RequestInfo); requestExamples != nil {
+					for _, example := range requestExamples {
+						example.Value = map[string]interface{}{"ServiceRequest": example.Value}
+					}
+					operation.RequestBody.Content["application/json"].Examples = requestExamples
+				}
+			}
+			if route.ResponseInfo != nil && route.ResponseInfo.Description != "" {
+				operation.Responses["200"].Description = route.ResponseInfo.Description
+			}
+			result.Paths[ServiceURIPrefix+service.ID()+"/"+route.Action+"/"] = &PathItem{Post: operation}
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package meta
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testTarget struct {
+	URL string `required:"true" description:"target URL"`
+}
+
+type testBase struct {
+	Name string `description:"base name"`
+}
+
+type testNode struct {
+	Next *testNode
+}
+
+type testRequest struct {
+	*testBase
+	Target    *testTarget `description:"request target"`
+	Targets   []*testTarget
+	Retries   int  `default:"3"`
+	Verbose   bool `json:"verbose,omitempty" default:"true"`
+	Payload   []byte
+	Params    map[string]interface{}
+	Node      *testNode
+	Timestamp time.Time
+	Skipped   string `json:"-"`
+	internal  string
+}
+
+func TestSchemaBuilder_Schema(t *testing.T) {
+	builder := &schemaBuilder{schemas: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+	schema := builder.Schema(reflect.TypeOf(&testRequest{}))
+	assert.Equal(t, "#/components/schemas/meta.testRequest", schema.Ref)
+	request := builder.schemas["meta.testRequest"]
+	if !assert.NotNil(t, request) {
+		return
+	}
+	assert.Equal(t, "object", request.Type)
+	assert.Equal(t, "base name", request.Properties["Name"].Description)
+	assert.Equal(t, "request target", request.Properties["Target"].Description)
+	assert.Equal(t, "#/components/schemas/meta.testTarget", request.Properties["Target"].AllOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/meta.testTarget", request.Properties["Targets"].Items.Ref)
+	assert.EqualValues(t, 3.0, request.Properties["Retries"].Default)
+	assert.EqualValues(t, true, request.Properties["verbose"].Default)
+	assert.Equal(t, "byte", request.Properties["Payload"].Format)
+	assert.Equal(t, "object", request.Properties["Params"].Type)
+	assert.Equal(t, "date-time", request.Properties["Timestamp"].Format)
+	assert.Nil(t, request.Properties["Skipped"])
+	assert.Nil(t, request.Properties["internal"])
+	assert.Equal(t, []string{"URL"}, builder.schemas["meta.testTarget"].Required)
+	assert.Equal(t, "#/components/schemas/meta.testNode", builder.schemas["meta.testNode"].Properties["Next"].Ref)
+}
+
+func TestService_OpenAPI(t *testing.T) {
+	service := New()
+	document := service.OpenAPI(nil)
+	assert.Equal(t, OpenAPIVersion, document.OpenAPI)
+	path, ok := document.Paths[ServiceURIPrefix+"nop/nop/"]
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, "nop_nop", path.Post.OperationID)
+	requestSchema := path.Post.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/endly.NopRequest", requestSchema.Properties["ServiceRequest"].Ref)
+	_, ok = document.Components.Schemas["endly.NopRequest"]
+	assert.True(t, ok)
+	_, err := json.Marshal(document)
+	assert.Nil(t, err)
+
+	document = service.OpenAPI(func(service, action string) bool {
+		return action != "nop"
+	})
+	_, ok = document.Paths[ServiceURIPrefix+"nop/nop/"]
+	assert.False(t, ok)
+}
+
+func TestService_Catalog(t *testing.T) {
+	service := New()
+	catalog := service.Catalog(nil)
+	var actions = make(map[string]*ActionEntry)
+	for _, entry := range catalog {
+		for _, action := range entry.Actions {
+			actions[entry.ID+":"+action.Action] = action
+		}
+	}
+	action, ok := actions["nop:nop"]
+	if assert.True(t, ok) {
+		assert.Equal(t, "*endly.NopRequest", action.Request)
+	}
+	assert.Equal(t, 0, len(service.Catalog(func(service, action string) bool { return false })))
+}
@@ -8,5 +8,6 @@ type AbstractNode struct {
 	Post        Variables `description:"post execution state update instruction"`
 	When        string    `description:"run criteria"`
 	SleepTimeMs int       //optional Sleep time
+	TimeoutMs   int       `description:"optional execution timeout in ms"`
 	Logging     *bool     `description:"optional flag to disable logging, enabled by default"`
 }
@@ -8,6 +8,7 @@ type Action struct {
 	*Repeater
 	Async bool   `description:"flag to run action async"`
 	Skip  string `description:"criteria to skip current TagID"`
+	Retry *Retry `description:"action retry policy with backoff"`
 }
 
 //NewActivity returns pipeline activity
@@ -34,6 +35,11 @@ func (a *Action) Init() error {
 		a.ServiceRequest = a.ServiceRequest.Init()
 	}
 	a.Repeater = a.Repeater.Init()
+	if a.Retry != nil {
+		if err := a.Retry.Init(); err != nil {
+			return err
+		}
+	}
 	if err := a.Validate(); err != nil {
 		return err
 	}
@@ -65,6 +71,7 @@ func (a *Action) Clone() *Action {
 		Repeater:       &repeater,
 		Async:          a.Async,
 		Skip:           a.Skip,
+		Retry:          a.Retry,
 	}
 }
 
@@ -2,6 +2,7 @@ package model
 
 import (
 	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
 	"time"
@@ -82,3 +83,7 @@ func NewActivityEndEvent(response interface{}) *ActivityEndEvent {
 		Response: response,
 	}
 }
+
+func init() {
+	msg.RegisterType(&Activity{}, &ActivityEndEvent{}, &ModifiedStateEvent{}, &ExtractEvent{})
+}
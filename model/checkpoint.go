@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+//Checkpoint represents persisted workflow process progress, used to resume interrupted run
+type Checkpoint struct {
+	URL       string
+	SessionID string
+	Workflow  string
+	Tasks     []string               `description:"completed task names"`
+	Actions   map[string][]string    `description:"completed action tag ids by task name"`
+	Process   map[string]interface{} `description:"process state"`
+	State     map[string]interface{} `description:"context state"`
+	Updated   time.Time
+	mux       sync.Mutex
+}
+
+//IsCompleted returns true if supplied task has been completed
+func (c *Checkpoint) IsCompleted(task string) bool {
+	if c == nil {
+		return false
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for _, candidate := range c.Tasks {
+		if candidate == task {
+			return true
+		}
+	}
+	return false
+}
+
+//AddTask marks supplied task as completed
+func (c *Checkpoint) AddTask(task string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for _, candidate := range c.Tasks {
+		if candidate == task {
+			return
+		}
+	}
+	c.Tasks = append(c.Tasks, task)
+	delete(c.Actions, task)
+}
+
+//AddAction marks supplied task action as completed
+func (c *Checkpoint) AddAction(task, tagID string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if len(c.Actions) == 0 {
+		c.Actions = make(map[string][]string)
+	}
+	c.Actions[task] = append(c.Actions[task], tagID)
+}
+
+//MarshalJSON encodes checkpoint while guarding concurrent task updates
+func (c *Checkpoint) MarshalJSON() ([]byte, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	type checkpoint Checkpoint
+	return json.Marshal((*checkpoint)(c))
+}
+
+//NewCheckpoint creates a new checkpoint
+func NewCheckpoint(URL, sessionID, workflow string) *Checkpoint {
+	return &Checkpoint{
+		URL:       URL,
+		SessionID: sessionID,
+		Workflow:  workflow,
+		Tasks:     make([]string, 0),
+		Actions:   make(map[string][]string),
+	}
+}
@@ -1,10 +1,13 @@
 package criteria
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/viant/assertly"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
+	"regexp"
+	"strings"
 )
 
 //Criterion represent evaluation criterion
@@ -15,11 +18,16 @@ type Criterion struct {
 	RightOperand interface{}
 }
 
-func (c *Criterion) expandOperand(opperand interface{}, state data.Map) interface{} {
-	if opperand == nil {
-		return nil
+func (c *Criterion) expandOperand(opperand interface{}, state data.Map) (interface{}, error) {
+	switch value := opperand.(type) {
+	case nil:
+		return nil, nil
+	case Expression:
+		return value.Evaluate(state)
+	case string:
+		return state.Expand(resolveUDFs(value, state)), nil
 	}
-	return state.Expand(opperand)
+	return state.Expand(opperand), nil
 }
 
 func checkUndefined(err error, left, right interface{}, operator string) error {
@@ -40,9 +48,14 @@ func (c *Criterion) Apply(state data.Map) (bool, error) {
 	if c.Predicate != nil && len(c.Predicate.Criteria) > 0 {
 		return c.Predicate.Apply(state)
 	}
-	leftOperand := c.expandOperand(c.LeftOperand, state)
-	rightOperand := c.expandOperand(c.RightOperand, state)
-	var err error
+	leftOperand, err := c.expandOperand(c.LeftOperand, state)
+	if err != nil {
+		return false, err
+	}
+	rightOperand, err := c.expandOperand(c.RightOperand, state)
+	if err != nil {
+		return false, err
+	}
 	var leftNumber, rightNumber float64
 	var rootPath = assertly.NewDataPath("/")
 	var context = assertly.NewDefaultContext()
@@ -102,11 +115,101 @@ func (c *Criterion) Apply(state data.Map) (bool, error) {
 				return leftNumber < rightNumber, nil
 			}
 		}
+	case "in", "not in":
+		member, err := isMember(leftOperand, rightOperand)
+		if err != nil {
+			return false, err
+		}
+		return member == (c.Operator == "in"), nil
+	case "contains":
+		return contains(leftOperand, rightOperand)
+	case "startsWith":
+		return strings.HasPrefix(toolbox.AsString(leftOperand), toolbox.AsString(rightOperand)), nil
+	case "~", "!~":
+		matched, err := matchesRegExpr(leftOperand, rightOperand)
+		if err != nil {
+			return false, err
+		}
+		return matched == (c.Operator == "~"), nil
 	}
 	err = checkUndefined(err, leftOperand, rightNumber, c.Operator)
 	return false, err
 }
 
+//asCollection returns slice elements, map keys, JSON array or comma separated text elements
+func asCollection(value interface{}) ([]interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if toolbox.IsSlice(value) {
+		return toolbox.AsSlice(value), nil
+	}
+	if toolbox.IsMap(value) {
+		var result = make([]interface{}, 0)
+		for key := range toolbox.AsMap(value) {
+			result = append(result, key)
+		}
+		return result, nil
+	}
+	text := strings.TrimSpace(toolbox.AsString(value))
+	var result = make([]interface{}, 0)
+	if strings.HasPrefix(text, "[") {
+		if err := json.Unmarshal([]byte(text), &result); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %v, %v", text, err)
+		}
+		return result, nil
+	}
+	for _, item := range strings.Split(text, ",") {
+		result = append(result, strings.Trim(strings.TrimSpace(item), "'\""))
+	}
+	return result, nil
+}
+
+//isEqual compares numbers numerically, other values as text
+func isEqual(left, right interface{}) bool {
+	if leftNumber, err := toolbox.ToFloat(left); err == nil {
+		if rightNumber, err := toolbox.ToFloat(right); err == nil {
+			return leftNumber == rightNumber
+		}
+	}
+	return toolbox.AsString(left) == toolbox.AsString(right)
+}
+
+//isMember returns true if candidate is an element of supplied collection
+func isMember(candidate, collection interface{}) (bool, error) {
+	items, err := asCollection(collection)
+	if err != nil {
+		return false, err
+	}
+	for _, item := range items {
+		if isEqual(candidate, item) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//contains returns true if text contains fragment, or slice or map keys contain element
+func contains(container, element interface{}) (bool, error) {
+	if toolbox.IsSlice(container) || toolbox.IsMap(container) {
+		return isMember(element, container)
+	}
+	return strings.Contains(toolbox.AsString(container), toolbox.AsString(element)), nil
+}
+
+//matchesRegExpr returns true if text matches /pattern/ or pattern expression
+func matchesRegExpr(text, expression interface{}) (bool, error) {
+	pattern := toolbox.AsString(expression)
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		pattern = string(pattern[1 : len(pattern)-1])
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regular expression: %v, %v", expression, err)
+	}
+	return compiled.MatchString(toolbox.AsString(text)), nil
+}
+
 //NewCriterion creates a new criterion
 func NewCriterion(leftOperand interface{}, operator string, rightOperand interface{}) *Criterion {
 	return &Criterion{
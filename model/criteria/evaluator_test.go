@@ -113,6 +113,69 @@ func Test_EvaluateCriteria(t *testing.T) {
 				"logRecords": []interface{}{"1"},
 			},
 		},
+		{
+			Description: "Arithmetic with UDF",
+			Expression:  "$len($items) * 2 + 1 > 6",
+			Expected:    true,
+			State: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+		},
+		{
+			Description: "Arithmetic precedence",
+			Expression:  "($count + 1) * 2 = 10 && $count % 2 = 0",
+			Expected:    true,
+			State: map[string]interface{}{
+				"count": 4,
+			},
+		},
+		{
+			Description: "In operator",
+			Expression:  "$status in [200, 204]",
+			Expected:    true,
+			State: map[string]interface{}{
+				"status": 204,
+			},
+		},
+		{
+			Description: "Not in operator",
+			Expression:  `$env not in ["dev", "qa"]`,
+			Expected:    true,
+			State: map[string]interface{}{
+				"env": "prod",
+			},
+		},
+		{
+			Description: "Contains and startsWith operators",
+			Expression:  "$output contains started && $output startsWith 'server '",
+			Expected:    true,
+			State: map[string]interface{}{
+				"output": "server started on 8080",
+			},
+		},
+		{
+			Description: "Regular expression operators",
+			Expression:  "$version ~ /^v[0-9]+\\.[0-9]+$/ && $version !~ /rc/",
+			Expected:    true,
+			State: map[string]interface{}{
+				"version": "v1.12",
+			},
+		},
+		{
+			Description: "Negated group",
+			Expression:  "!($env = prod || $status > 299)",
+			Expected:    false,
+		},
+		{
+			Description: "Unclosed JSON array",
+			Expression:  "$status in [200, 204",
+			HasError:    true,
+		},
+		{
+			Description: "Division by zero",
+			Expression:  "$count / 0 > 1",
+			HasError:    true,
+		},
 		{
 			Description:   "Uni operand expression",
 			Expression:    "$getTrue()", //
@@ -0,0 +1,178 @@
+package criteria
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const arithmeticOperators = "+-*/%"
+
+var udfCallExpression = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)\(`)
+
+//Expression represents arithmetic operand expression, operators have to be separated with whitespaces, i.e. $Len($items) * 2 + 1
+type Expression string
+
+//Evaluate expands expression terms with supplied state and applies arithmetic operators, multiplicative operators take precedence
+func (e Expression) Evaluate(state data.Map) (interface{}, error) {
+	terms, operators, err := splitArithmetic(string(e))
+	if err != nil {
+		return nil, err
+	}
+	var values = make([]interface{}, len(terms))
+	for i, term := range terms {
+		if values[i], err = evaluateTerm(term, state); err != nil {
+			return nil, err
+		}
+	}
+	var reducedValues = []interface{}{values[0]}
+	var reducedOperators = make([]string, 0)
+	for i, operator := range operators {
+		if operator == "+" || operator == "-" {
+			reducedValues = append(reducedValues, values[i+1])
+			reducedOperators = append(reducedOperators, operator)
+			continue
+		}
+		last := len(reducedValues) - 1
+		if reducedValues[last], err = applyArithmetic(reducedValues[last], operator, values[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	var result = reducedValues[0]
+	for i, operator := range reducedOperators {
+		if result, err = applyArithmetic(result, operator, reducedValues[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+//splitArithmetic splits expression into terms and whitespace separated top level operators
+func splitArithmetic(expression string) ([]string, []string, error) {
+	var terms = make([]string, 0)
+	var operators = make([]string, 0)
+	var depth = 0
+	var inQuotes = false
+	var begin = 0
+	for i := 0; i < len(expression); i++ {
+		switch char := expression[i]; char {
+		case '\'':
+			inQuotes = !inQuotes
+		case '(', '[', '{':
+			if !inQuotes {
+				depth++
+			}
+		case ')', ']', '}':
+			if !inQuotes {
+				depth--
+			}
+		default:
+			if inQuotes || depth > 0 || !strings.ContainsRune(arithmeticOperators, rune(char)) {
+				continue
+			}
+			if i == 0 || i+1 >= len(expression) || !isWhitespace(expression[i-1]) || !isWhitespace(expression[i+1]) {
+				continue
+			}
+			terms = append(terms, strings.TrimSpace(expression[begin:i]))
+			operators = append(operators, string(char))
+			begin = i + 1
+		}
+	}
+	terms = append(terms, strings.TrimSpace(expression[begin:]))
+	for _, term := range terms {
+		if term == "" {
+			return nil, nil, fmt.Errorf("invalid arithmetic expression: %v", expression)
+		}
+	}
+	return terms, operators, nil
+}
+
+func isWhitespace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n'
+}
+
+//evaluateTerm returns quoted literal, evaluated parenthesized expression or expanded term
+func evaluateTerm(term string, state data.Map) (interface{}, error) {
+	if len(term) >= 2 && term[0] == '\'' && term[len(term)-1] == '\'' {
+		return string(term[1 : len(term)-1]), nil
+	}
+	if isEnclosed(term) {
+		return Expression(term[1 : len(term)-1]).Evaluate(state)
+	}
+	return state.Expand(resolveUDFs(term, state)), nil
+}
+
+//isEnclosed returns true if opening parenthesis is closed at the end of the term
+func isEnclosed(term string) bool {
+	if !strings.HasPrefix(term, "(") || !strings.HasSuffix(term, ")") {
+		return false
+	}
+	var depth = 0
+	for i := 0; i < len(term); i++ {
+		switch term[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i == len(term)-1
+			}
+		}
+	}
+	return false
+}
+
+//resolveUDFs replaces case insensitive UDF names with names registered in the state, i.e. $len($items) with $Len($items)
+func resolveUDFs(expression string, state data.Map) string {
+	if !strings.Contains(expression, "(") {
+		return expression
+	}
+	return udfCallExpression.ReplaceAllStringFunc(expression, func(call string) string {
+		name := udfCallExpression.FindStringSubmatch(call)[1]
+		if state.Has(name) {
+			return call
+		}
+		for key, value := range state {
+			if _, ok := value.(func(interface{}, data.Map) (interface{}, error)); ok && strings.EqualFold(key, name) {
+				return strings.Replace(call, name, key, 1)
+			}
+		}
+		return call
+	})
+}
+
+func applyArithmetic(left interface{}, operator string, right interface{}) (interface{}, error) {
+	leftNumber, leftErr := toolbox.ToFloat(left)
+	rightNumber, rightErr := toolbox.ToFloat(right)
+	if leftErr != nil || rightErr != nil {
+		if operator == "+" {
+			return toolbox.AsString(left) + toolbox.AsString(right), nil
+		}
+		return nil, fmt.Errorf("unable to evaluate %v %v %v: operand is not a number", left, operator, right)
+	}
+	var result float64
+	switch operator {
+	case "+":
+		result = leftNumber + rightNumber
+	case "-":
+		result = leftNumber - rightNumber
+	case "*":
+		result = leftNumber * rightNumber
+	case "/", "%":
+		if rightNumber == 0 {
+			return nil, fmt.Errorf("unable to evaluate %v %v %v: division by zero", left, operator, right)
+		}
+		if operator == "/" {
+			result = leftNumber / rightNumber
+		} else {
+			result = math.Mod(leftNumber, rightNumber)
+		}
+	}
+	if result == math.Trunc(result) && math.Abs(result) < math.MaxInt64 {
+		return int(result), nil
+	}
+	return result, nil
+}
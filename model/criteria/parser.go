@@ -19,15 +19,22 @@ const (
 	jsonObject
 	jsonArray
 	grouping
+	arithmeticOperator
+	regExpr
 )
 
+const maxErrorFragmentLength = 20
+
 var matchers = map[int]toolbox.Matcher{
 	eof:         toolbox.EOFMatcher{},
 	whitespaces: toolbox.CharactersMatcher{" \n\t"},
 	operand:     toolbox.NewCustomIdMatcher(".", "_", "$", "[", "]", "{", "}", "!", "-", "/", "\\", "+", "-", "*"),
-	operator: toolbox.KeywordsMatcher{
-		Keywords:      []string{"=", ">=", "<=", "<>", ">", "<", "!=", ":"},
-		CaseSensitive: false,
+	operator: &operatorMatcher{
+		symbols: toolbox.KeywordsMatcher{
+			Keywords:      []string{"=", ">=", "<=", "<>", ">", "<", "!=", "!~", "~", ":"},
+			CaseSensitive: false,
+		},
+		words: []string{"not in", "in", "contains", "startsWith"},
 	},
 	logicalOperator: toolbox.KeywordsMatcher{
 		Keywords:      []string{"&&", "||"},
@@ -37,13 +44,91 @@ var matchers = map[int]toolbox.Matcher{
 	grouping:            &toolbox.BodyMatcher{"(", ")"},
 	jsonObject:          &toolbox.BodyMatcher{"{", "}"},
 	jsonArray:           &toolbox.BodyMatcher{"[", "]"},
+	regExpr:             &toolbox.BodyMatcher{Begin: "/", End: "/"},
+	arithmeticOperator:  arithmeticOperatorMatcher{},
 	assertlyExprMatcher: toolbox.NewSequenceMatcher("&&", "||"),
 }
 
+//operatorMatcher represents comparison operator matcher, word operators have to be followed by a non word character
+type operatorMatcher struct {
+	symbols toolbox.KeywordsMatcher
+	words   []string
+}
+
+//Match matches symbol or word operator, it returns number of characters matched
+func (m *operatorMatcher) Match(input string, offset int) int {
+	if matched := m.symbols.Match(input, offset); matched > 0 {
+		return matched
+	}
+	for _, word := range m.words {
+		if matched := matchWords(input, offset, strings.Fields(word)); matched > 0 {
+			return matched
+		}
+	}
+	return 0
+}
+
+//matchWords matches whitespace separated words, it returns number of characters matched
+func matchWords(input string, offset int, words []string) int {
+	var index = offset
+	for i, word := range words {
+		if i > 0 {
+			var spaces = 0
+			for index+spaces < len(input) && isWhitespace(input[index+spaces]) {
+				spaces++
+			}
+			if spaces == 0 {
+				return 0
+			}
+			index += spaces
+		}
+		if !strings.HasPrefix(input[index:], word) {
+			return 0
+		}
+		index += len(word)
+	}
+	if index < len(input) && isWordCharacter(input[index]) {
+		return 0
+	}
+	return index - offset
+}
+
+func isWordCharacter(char byte) bool {
+	return char == '_' || (char >= '0' && char <= '9') || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+//arithmeticOperatorMatcher represents arithmetic operator matcher, operator has to be followed by a whitespace
+type arithmeticOperatorMatcher struct{}
+
+//Match matches arithmetic operator, it returns number of characters matched
+func (m arithmeticOperatorMatcher) Match(input string, offset int) int {
+	if offset+1 < len(input) && strings.IndexByte(arithmeticOperators, input[offset]) != -1 && isWhitespace(input[offset+1]) {
+		return 1
+	}
+	return 0
+}
+
 //Parser represents endly criteria parser
 type Parser struct{}
 
-func (p *Parser) expectOptionalWhitespaceFollowedBy(tokenizer *toolbox.Tokenizer, expectedTokensMessage string, expected ...int) (*toolbox.Token, error) {
+//expectClosedGrouping returns parsing error if grouping token is not closed, grouping matcher matches unbalanced grouping till the end of input
+func (p *Parser) expectClosedGrouping(tokenizer *toolbox.Tokenizer, offset int, token *toolbox.Token) error {
+	var depth = 0
+	for i := 0; i < len(token.Matched); i++ {
+		switch token.Matched[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+	}
+	if depth > 0 {
+		return newIllegalTokenParsingError(tokenizer, offset, ")")
+	}
+	return nil
+}
+
+func (p *Parser) expectOptionalWhitespaceFollowedBy(tokenizer *toolbox.Tokenizer, offset int, expectedTokensMessage string, expected ...int) (*toolbox.Token, error) {
 	var expectedTokens = make([]int, 0)
 	expectedTokens = append(expectedTokens, whitespaces)
 	expectedTokens = append(expectedTokens, expected...)
@@ -51,26 +136,98 @@ func (p *Parser) expectOptionalWhitespaceFollowedBy(tokenizer *toolbox.Tokenizer
 	token := tokenizer.Nexts(expectedTokens...)
 
 	if token.Token == eof && !toolbox.HasSliceAnyElements(expectedTokens, eof) {
-		return nil, newIllegalTokenParsingError(tokenizer.Index, expectedTokensMessage)
+		return nil, newIllegalTokenParsingError(tokenizer, offset, expectedTokensMessage)
 	}
 
 	if token.Token == illegal {
-		return nil, newIllegalTokenParsingError(tokenizer.Index, expectedTokensMessage)
+		return nil, newIllegalTokenParsingError(tokenizer, offset, expectedTokensMessage)
 	}
 	if token.Token == whitespaces {
 		token = tokenizer.Nexts(expected...)
 	}
+	if token.Token == eof && !toolbox.HasSliceAnyElements(expected, eof) {
+		return nil, newIllegalTokenParsingError(tokenizer, offset, expectedTokensMessage)
+	}
 	if token.Token == illegal {
-		return nil, newIllegalTokenParsingError(tokenizer.Index, expectedTokensMessage)
+		return nil, newIllegalTokenParsingError(tokenizer, offset, expectedTokensMessage)
 	}
 	if token.Token == eof && len(token.Matched) > 0 {
-		return nil, newIllegalTokenParsingError(tokenizer.Index, expectedTokensMessage)
+		return nil, newIllegalTokenParsingError(tokenizer, offset, expectedTokensMessage)
 	}
 	return token, nil
 }
 
+//isFollowedByOperator returns true if the next token is comparison or arithmetic operator, tokenizer position is not changed
+func (p *Parser) isFollowedByOperator(tokenizer *toolbox.Tokenizer) bool {
+	index := tokenizer.Index
+	defer func() { tokenizer.Index = index }()
+	tokenizer.Next(whitespaces)
+	token := tokenizer.Nexts(operator, arithmeticOperator)
+	return token.Token == operator || token.Token == arithmeticOperator
+}
+
+//arithmeticTail returns whitespace separated arithmetic operations following an operand, i.e. " * 2 + $b"
+func (p *Parser) arithmeticTail(tokenizer *toolbox.Tokenizer, offset int) (string, error) {
+	var result = ""
+	for {
+		index := tokenizer.Index
+		tokenizer.Next(whitespaces)
+		arithmetic := tokenizer.Next(arithmeticOperator)
+		if arithmetic.Token != arithmeticOperator {
+			tokenizer.Index = index
+			return result, nil
+		}
+		token, err := p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "arithmetic operand", quoted, jsonArray, grouping, operand)
+		if err != nil {
+			return "", err
+		}
+		if token.Token == grouping {
+			if err = p.expectClosedGrouping(tokenizer, offset, token); err != nil {
+				return "", err
+			}
+		}
+		var matched = token.Matched
+		if token.Token == operand {
+			if call := tokenizer.Next(grouping); call.Token == grouping {
+				if err = p.expectClosedGrouping(tokenizer, offset, call); err != nil {
+					return "", err
+				}
+				matched += call.Matched
+			}
+		}
+		result += " " + arithmetic.Matched + " " + matched
+	}
+}
+
+//operandValue returns operand or arithmetic expression if operand is followed by arithmetic operators
+func (p *Parser) operandValue(tokenizer *toolbox.Tokenizer, offset int, token *toolbox.Token) (interface{}, error) {
+	var matched, raw = token.Matched, token.Matched
+	if token.Token == quoted {
+		matched = strings.Trim(token.Matched, "' ")
+	}
+	if token.Token != regExpr {
+		if call := tokenizer.Next(grouping); call.Token == grouping {
+			if err := p.expectClosedGrouping(tokenizer, offset, call); err != nil {
+				return nil, err
+			}
+			matched += call.Matched
+			raw += call.Matched
+		}
+	}
+	tail, err := p.arithmeticTail(tokenizer, offset)
+	if err != nil || tail == "" {
+		return matched, err
+	}
+	return Expression(raw + tail), nil
+}
+
 //Parse parses supplied expression. It returns criteria or parsing error.
 func (p *Parser) Parse(expression string) (*Predicate, error) {
+	return p.parse(expression, 0)
+}
+
+//parse parses expression located at offset of the top level expression, offset is used to report absolute error position
+func (p *Parser) parse(expression string, offset int) (*Predicate, error) {
 	result := NewPredicate("")
 	tokenizer := toolbox.NewTokenizer(expression, illegal, eof, matchers)
 	var criterion *Criterion
@@ -81,7 +238,7 @@ func (p *Parser) Parse(expression string) (*Predicate, error) {
 	parsingCriteria := result
 
 	setUniOperandCriteriaIfNeeed := func(criterion *Criterion) {
-		if criterion.Operator != "" || criterion.RightOperand != nil {
+		if criterion == nil || criterion.Operator != "" || criterion.RightOperand != nil {
 			return
 		}
 		criterion.Operator = "!="
@@ -91,18 +248,32 @@ outer:
 	for {
 
 		expectedTokens := leftOperandTokens
-		if criterion != nil && criterion.Operator != "" {
+		if criterion != nil && criterion.Operator != "" && criterion.RightOperand == nil {
 			expectedTokens = rightOperandTokens
 		}
-		token, err := p.expectOptionalWhitespaceFollowedBy(tokenizer, "id or grouping expression", expectedTokens...)
+		token, err := p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "id or grouping expression", expectedTokens...)
 		if err != nil {
 			return nil, err
 		}
 		switch token.Token {
 
 		case grouping:
+			if err = p.expectClosedGrouping(tokenizer, offset, token); err != nil {
+				return nil, err
+			}
+			if p.isFollowedByOperator(tokenizer) {
+				tail, err := p.arithmeticTail(tokenizer, offset)
+				if err != nil {
+					return nil, err
+				}
+				criterion = &Criterion{
+					LeftOperand: Expression(token.Matched + tail),
+				}
+				parsingCriteria.Criteria = append(parsingCriteria.Criteria, criterion)
+				break
+			}
 			groupingExpression := string(token.Matched[1 : len(token.Matched)-1])
-			criteria, err := p.Parse(groupingExpression)
+			criteria, err := p.parse(groupingExpression, offset+tokenizer.Index-len(token.Matched)+1)
 			if err != nil {
 				return nil, err
 			}
@@ -117,16 +288,31 @@ outer:
 			}
 
 		case operand, jsonObject, jsonArray, quoted:
-			var matched = token.Matched
-			if token.Token == quoted {
-				matched = strings.Trim(token.Matched, "' ")
+			if token.Token == operand && token.Matched == "!" {
+				group := tokenizer.Next(grouping)
+				if group.Token != grouping {
+					return nil, newIllegalTokenParsingError(tokenizer, offset, "grouping expression")
+				}
+				if err = p.expectClosedGrouping(tokenizer, offset, group); err != nil {
+					return nil, err
+				}
+				groupingExpression := string(group.Matched[1 : len(group.Matched)-1])
+				criteria, err := p.parse(groupingExpression, offset+tokenizer.Index-len(group.Matched)+1)
+				if err != nil {
+					return nil, err
+				}
+				criteria.Negated = true
+				parsingCriteria.Criteria = append(parsingCriteria.Criteria, &Criterion{
+					Predicate: criteria,
+				})
+				break
 			}
-			token = tokenizer.Next(grouping)
-			if token != nil {
-				matched += token.Matched
+			leftOperand, err := p.operandValue(tokenizer, offset, token)
+			if err != nil {
+				return nil, err
 			}
 			criterion = &Criterion{
-				LeftOperand: matched,
+				LeftOperand: leftOperand,
 			}
 			parsingCriteria.Criteria = append(parsingCriteria.Criteria, criterion)
 		case operator:
@@ -135,7 +321,7 @@ outer:
 		}
 
 		if token.Token != operator {
-			token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, "operator", operator, logicalOperator, eof)
+			token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "operator", operator, logicalOperator, eof)
 			if err != nil {
 				return nil, err
 			}
@@ -144,19 +330,23 @@ outer:
 		if token.Token == eof {
 			break outer
 		} else if token.Token == operator {
-
-			leftOperand := toolbox.AsString(criterion.LeftOperand)
-			if strings.HasSuffix(leftOperand, "!") {
+			var matchedOperator = strings.Join(strings.Fields(token.Matched), " ")
+			if leftOperand, ok := criterion.LeftOperand.(string); ok && strings.HasSuffix(leftOperand, "!") {
 				criterion.LeftOperand = string(leftOperand[:len(leftOperand)-1])
-				criterion.Operator = "!" + token.Matched
+				criterion.Operator = "!" + matchedOperator
 			} else {
-				criterion.Operator = token.Matched
+				criterion.Operator = matchedOperator
 			}
 
-			if criterion.Operator == ":" {
-				token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, "right operand", assertlyExprMatcher, eof)
-			} else {
-				token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, "right operand", quoted, jsonObject, jsonArray, operand, eof)
+			switch criterion.Operator {
+			case ":":
+				token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "right operand", assertlyExprMatcher, eof)
+			case "~", "!~":
+				token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "regular expression", regExpr, quoted, operand)
+			case "in", "!in", "not in", "!not in", "contains", "!contains", "startsWith", "!startsWith":
+				token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "right operand", quoted, jsonObject, jsonArray, operand)
+			default:
+				token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "right operand", quoted, jsonObject, jsonArray, operand, eof)
 			}
 			if err != nil {
 				return nil, err
@@ -164,17 +354,17 @@ outer:
 			if token.Token == eof {
 				break outer
 			}
-			var matched = token.Matched
-			if token.Token == quoted {
-				matched = strings.Trim(token.Matched, "' ")
-			}
-
-			match := tokenizer.Nexts(grouping, eof)
-			if match != nil && match.Token == grouping {
-				matched += match.Matched
+			if token.Token == assertlyExprMatcher {
+				var matched = token.Matched
+				match := tokenizer.Nexts(grouping, eof)
+				if match != nil && match.Token == grouping {
+					matched += match.Matched
+				}
+				criterion.RightOperand = matched
+			} else if criterion.RightOperand, err = p.operandValue(tokenizer, offset, token); err != nil {
+				return nil, err
 			}
-			criterion.RightOperand = matched
-			token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, "logical conjunction", logicalOperator, eof)
+			token, err = p.expectOptionalWhitespaceFollowedBy(tokenizer, offset, "logical conjunction", logicalOperator, eof)
 			if err != nil {
 				return nil, err
 			}
@@ -216,6 +406,15 @@ func (e illegalTokenParsingError) Error() string {
 	return e.error
 }
 
-func newIllegalTokenParsingError(index int, expected string) error {
-	return &illegalTokenParsingError{Index: index, Expected: expected, error: fmt.Sprintf("illegal token at %v, expected %v", index, expected)}
+//newIllegalTokenParsingError creates an error for illegal token at tokenizer position, offset is the tokenizer input position in the top level expression
+func newIllegalTokenParsingError(tokenizer *toolbox.Tokenizer, offset int, expected string) error {
+	var index = offset + tokenizer.Index
+	var message = fmt.Sprintf("illegal token at %v, expected %v", index, expected)
+	if found := string(tokenizer.Input[tokenizer.Index:]); found != "" {
+		if len(found) > maxErrorFragmentLength {
+			found = string(found[:maxErrorFragmentLength]) + "..."
+		}
+		message += fmt.Sprintf(", found: %v", found)
+	}
+	return &illegalTokenParsingError{Index: index, Expected: expected, error: message}
 }
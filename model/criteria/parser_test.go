@@ -121,3 +121,115 @@ func TestCriteriaParser_Parse(t *testing.T) {
 	}
 
 }
+
+func TestCriteriaParser_ParseExtended(t *testing.T) {
+
+	parser := criteria.NewParser()
+
+	var useCases = []struct {
+		Description string
+		Expression  string
+		Expected    *criteria.Predicate
+		Error       string
+	}{
+		{
+			Description: "arithmetic left operand",
+			Expression:  "$Len($items) * 2 + 1 > 2",
+			Expected:    criteria.NewPredicate("", criteria.NewCriterion(criteria.Expression("$Len($items) * 2 + 1"), ">", "2")),
+		},
+		{
+			Description: "arithmetic grouping operand",
+			Expression:  "($a + 1) * 2 = $b - 1",
+			Expected:    criteria.NewPredicate("", criteria.NewCriterion(criteria.Expression("($a + 1) * 2"), "=", criteria.Expression("$b - 1"))),
+		},
+		{
+			Description: "operand with dash is not arithmetic",
+			Expression:  "$date = 2019-01-01",
+			Expected:    criteria.NewPredicate("", criteria.NewCriterion("$date", "=", "2019-01-01")),
+		},
+		{
+			Description: "in operator",
+			Expression:  "$status in [200,204] && $env not  in [dev,qa]",
+			Expected: criteria.NewPredicate("&&",
+				criteria.NewCriterion("$status", "in", "[200,204]"),
+				criteria.NewCriterion("$env", "not in", "[dev,qa]")),
+		},
+		{
+			Description: "word operators",
+			Expression:  "$items contains 2 || $name startsWith 'ab'",
+			Expected: criteria.NewPredicate("||",
+				criteria.NewCriterion("$items", "contains", "2"),
+				criteria.NewCriterion("$name", "startsWith", "ab")),
+		},
+		{
+			Description: "regular expression operator",
+			Expression:  "$name ~ /^a(b|c)$/",
+			Expected:    criteria.NewPredicate("", criteria.NewCriterion("$name", "~", "/^a(b|c)$/")),
+		},
+		{
+			Description: "negated group",
+			Expression:  "$k0 && !($k1 || $k2)",
+			Expected: criteria.NewPredicate("&&",
+				criteria.NewCriterion("$k0", "!=", nil),
+				&criteria.Criterion{
+					Predicate: &criteria.Predicate{
+						LogicalOperator: "||",
+						Negated:         true,
+						Criteria: []*criteria.Criterion{
+							criteria.NewCriterion("$k1", "", nil),
+							criteria.NewCriterion("$k2", "!=", nil),
+						},
+					},
+				}),
+		},
+		{
+			Description: "word operator needs word boundary",
+			Expression:  "$a inx 1",
+			Error:       "illegal token at 3, expected operator, found: inx 1",
+		},
+		{
+			Description: "nested group error position",
+			Expression:  "$a = 1 && ($b = 2 && $c $d)",
+			Error:       "illegal token at 24, expected operator, found: $d",
+		},
+		{
+			Description: "missing arithmetic operand",
+			Expression:  "$a + && $b",
+			Error:       "illegal token at 5, expected arithmetic operand, found: && $b",
+		},
+		{
+			Description: "unclosed group",
+			Expression:  "$a = 1 && ($b = 2",
+			Error:       "illegal token at 17, expected )",
+		},
+		{
+			Description: "unclosed nested group",
+			Expression:  "(($a = 1)",
+			Error:       "illegal token at 9, expected )",
+		},
+		{
+			Description: "missing in operand",
+			Expression:  "$x in",
+			Error:       "illegal token at 5, expected right operand",
+		},
+		{
+			Description: "missing regular expression",
+			Expression:  "$a ~ ",
+			Error:       "illegal token at 5, expected regular expression",
+		},
+	}
+
+	for _, useCase := range useCases {
+		predicate, err := parser.Parse(useCase.Expression)
+		if useCase.Error != "" {
+			if assert.NotNil(t, err, useCase.Description) {
+				assert.EqualValues(t, useCase.Error, err.Error(), useCase.Description)
+			}
+			continue
+		}
+		if !assert.Nil(t, err, useCase.Description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.Expected, predicate, useCase.Description)
+	}
+}
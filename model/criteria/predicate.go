@@ -8,10 +8,19 @@ import (
 type Predicate struct {
 	LogicalOperator string
 	Criteria        []*Criterion
+	Negated         bool `json:",omitempty"`
 }
 
 //Apply evaluates criteria with supplied context and state map . Dolar prefixed $expression will be expanded before evaluation.
 func (c *Predicate) Apply(state data.Map) (bool, error) {
+	result, err := c.apply(state)
+	if c.Negated && err == nil {
+		return !result, nil
+	}
+	return result, err
+}
+
+func (c *Predicate) apply(state data.Map) (bool, error) {
 	if c.LogicalOperator == "||" {
 		for _, criterion := range c.Criteria {
 			result, err := criterion.Apply(state)
@@ -17,22 +17,25 @@ const (
 	ExplicitActionAttributePrefix  = ":"
 	ExplicitRequestAttributePrefix = "@"
 
-	requestKey     = "request"
-	failKey        = "fail"
-	parentKey      = "parent"
-	actionKey      = "action"
-	whenKey        = "when"
-	serviceKey     = "service"
-	workflowKey    = "workflow"
-	skipKey        = "skip"
-	loggingKey     = "logging"
-	descriptionKey = "description"
-	commentsKey    = "comments"
-	initKey        = "init"
-	postKey        = "post"
-	exitKey        = "exit"
-	tagKey         = "tag"
-	defaultPath    = "default"
+	requestKey        = "request"
+	failKey           = "fail"
+	parentKey         = "parent"
+	actionKey         = "action"
+	whenKey           = "when"
+	serviceKey        = "service"
+	workflowKey       = "workflow"
+	skipKey           = "skip"
+	loggingKey        = "logging"
+	descriptionKey    = "description"
+	commentsKey       = "comments"
+	initKey           = "init"
+	postKey           = "post"
+	exitKey           = "exit"
+	tagKey            = "tag"
+	dependsOnKey      = "dependson"
+	maxParallelismKey = "maxparallelism"
+	retryKey          = "retry"
+	defaultPath       = "default"
 )
 
 var multiActionKeys = []string{"multiaction", "async"}
@@ -43,21 +46,24 @@ type MapEntry struct {
 }
 
 type InlineWorkflow struct {
-	baseURL    string
-	tagPathURL string
-	name       string
-	Init       interface{}
-	Post       interface{}
-	Logging    *bool
-	Defaults   map[string]interface{}
-	Data       map[string]interface{}
-	Pipeline   []*MapEntry
-	State      data.Map
-	workflow   *Workflow //inline workflow from pipeline
+	baseURL        string
+	tagPathURL     string
+	name           string
+	Init           interface{}
+	Post           interface{}
+	Logging        *bool
+	MaxParallelism int `description:"max number of pipeline tasks running concurrently when tasks declare dependsOn"`
+	TimeoutMs      int `description:"optional pipeline execution timeout in ms"`
+	Defaults       map[string]interface{}
+	Data           map[string]interface{}
+	Parameters     Parameters `description:"workflow parameters schema, validated before run"`
+	Pipeline       []*MapEntry
+	State          data.Map
+	workflow       *Workflow //inline workflow from pipeline
 }
 
 func (p InlineWorkflow) updateReservedAttributes(aMap map[string]interface{}) {
-	for _, key := range []string{actionKey, workflowKey, skipKey, whenKey, postKey, initKey, commentsKey, descriptionKey, failKey} {
+	for _, key := range []string{actionKey, workflowKey, skipKey, whenKey, postKey, initKey, commentsKey, descriptionKey, failKey, retryKey} {
 		if val, ok := aMap[key]; ok {
 			if _, has := aMap[ExplicitActionAttributePrefix+key]; has {
 				continue
@@ -237,14 +243,16 @@ func (p *InlineWorkflow) AsWorkflow(name string, baseURL string) (*Workflow, err
 	}
 	var workflow = &Workflow{
 		AbstractNode: &AbstractNode{
-			Name:    name,
-			Logging: p.Logging,
+			Name:      name,
+			Logging:   p.Logging,
+			TimeoutMs: p.TimeoutMs,
 		},
 		TasksNode: &TasksNode{
 			Tasks: []*Task{},
 		},
-		Data:   p.Data,
-		Source: url.NewResource(toolbox.URLPathJoin(baseURL, name+".yaml")),
+		Data:       p.Data,
+		Parameters: p.Parameters,
+		Source:     url.NewResource(toolbox.URLPathJoin(baseURL, name+".yaml")),
 	}
 	var err error
 	if p.Init != nil {
@@ -271,6 +279,7 @@ func (p *InlineWorkflow) AsWorkflow(name string, baseURL string) (*Workflow, err
 	if len(root.Tasks) > 0 {
 		p.normalize(root.TasksNode)
 		workflow.TasksNode = root.TasksNode
+		workflow.TasksNode.MaxParallelism = p.MaxParallelism
 	} else {
 		workflow.TasksNode = &TasksNode{
 			Tasks: []*Task{root},
@@ -420,6 +429,7 @@ func (p *InlineWorkflow) buildWorkflowNodes(name string, source interface{}, par
 	}
 
 	if isActionNode(actionAttributes) {
+		var graphAttributes = extractTaskGraphAttributes(actionAttributes, actionRequest)
 		if isNormalizableRequest(actionAttributes) {
 			if normalized, err := util.NormalizeMap(actionRequest, true); err == nil {
 				actionRequest = normalized
@@ -433,6 +443,9 @@ func (p *InlineWorkflow) buildWorkflowNodes(name string, source interface{}, par
 		if !parentTask.multiAction {
 			task = p.buildTask(name, map[string]interface{}{})
 			parentTask.Tasks = append(parentTask.Tasks, task)
+			for key, value := range graphAttributes {
+				setTaskGraphAttribute(task, key, value)
+			}
 		}
 
 		if action.Description != "" && task.Description == "" {
@@ -466,6 +479,10 @@ func (p *InlineWorkflow) buildWorkflowNodes(name string, source interface{}, par
 		if textKey == loggingKey || textKey == whenKey || textKey == descriptionKey || textKey == failKey { //abstract node attributes
 			nodeAttributes[textKey] = value
 		}
+		if textKey == dependsOnKey || textKey == maxParallelismKey { //task graph attributes
+			setTaskGraphAttribute(task, textKey, value)
+			return true
+		}
 		flagAsMultiActionIfMatched(textKey, task, value)
 		if value == nil || !toolbox.IsSlice(value) {
 			return true
@@ -502,6 +519,40 @@ func (p *InlineWorkflow) buildWorkflowNodes(name string, source interface{}, par
 	return buildErr
 }
 
+//extractTaskGraphAttributes removes task graph attributes from action attributes and request
+func extractTaskGraphAttributes(actionAttributes, actionRequest map[string]interface{}) map[string]interface{} {
+	var result = make(map[string]interface{})
+	for key, value := range actionAttributes {
+		textKey := strings.ToLower(key)
+		if textKey == dependsOnKey || textKey == maxParallelismKey {
+			result[textKey] = value
+			delete(actionAttributes, key)
+			delete(actionRequest, key)
+		}
+	}
+	return result
+}
+
+func setTaskGraphAttribute(task *Task, textKey string, value interface{}) {
+	if task == nil || value == nil {
+		return
+	}
+	switch textKey {
+	case dependsOnKey:
+		if toolbox.IsSlice(value) {
+			for _, item := range toolbox.AsSlice(value) {
+				task.DependsOn = append(task.DependsOn, strings.TrimSpace(toolbox.AsString(item)))
+			}
+			return
+		}
+		for _, item := range strings.Split(toolbox.AsString(value), ",") {
+			task.DependsOn = append(task.DependsOn, strings.TrimSpace(item))
+		}
+	case maxParallelismKey:
+		task.MaxParallelism = toolbox.AsInt(value)
+	}
+}
+
 func flagAsMultiActionIfMatched(textKey string, task *Task, value interface{}) {
 	for _, key := range multiActionKeys {
 		if textKey == key && toolbox.IsBool(value) {
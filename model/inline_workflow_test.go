@@ -395,6 +395,36 @@ pipeline:
 			"Name": "aero"
 		}
 	]
+}`,
+		},
+		{
+			Description: "task dependencies pipeline",
+			YAMLData: `maxParallelism: 2
+pipeline:
+  build:
+    action: nop
+  test:
+    dependsOn: build
+    action: nop
+  deploy:
+    dependsOn: [test, build]
+    action: nop
+`,
+			Expected: `{
+	"MaxParallelism": 2,
+	"Tasks": [
+		{
+			"Name": "build"
+		},
+		{
+			"DependsOn": ["build"],
+			"Name": "test"
+		},
+		{
+			"DependsOn": ["test", "build"],
+			"Name": "deploy"
+		}
+	]
 }`,
 		},
 	}
@@ -53,13 +53,7 @@ func (e *event) Package() string {
 
 //Type returns event type (simple package and struct name)
 func (e *event) Type() string {
-	var eventType = fmt.Sprintf("%T", e.value)
-	eventType = strings.Replace(eventType, "*", "", len(eventType))
-	var fragments = strings.Split(eventType, ".")
-	if len(fragments) > 2 {
-		fragments = fragments[len(fragments)-2:]
-	}
-	return strings.Join(fragments, "_")
+	return TypeName(e.value)
 }
 
 //NewEvent creates a new event
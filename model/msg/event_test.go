@@ -26,3 +26,17 @@ func TestEvent_Type(t *testing.T) {
 		assert.EqualValues(t, "<nil>", event.Type())
 	}
 }
+
+func TestRegisterType(t *testing.T) {
+	RegisterType(&os.File{})
+	value, ok := NewValue("os_File")
+	assert.True(t, ok)
+	_, ok = value.(*os.File)
+	assert.True(t, ok)
+	value, ok = NewValue("msg_ErrorEvent")
+	assert.True(t, ok)
+	_, ok = value.(*ErrorEvent)
+	assert.True(t, ok)
+	_, ok = NewValue("abc_Event")
+	assert.False(t, ok)
+}
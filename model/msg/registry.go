@@ -0,0 +1,66 @@
+package msg
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+var registry = struct {
+	types map[string]reflect.Type
+	mux   sync.RWMutex
+}{types: make(map[string]reflect.Type)}
+
+//TypeName returns event type name for supplied value (simple package and struct name)
+func TypeName(value interface{}) string {
+	var eventType = fmt.Sprintf("%T", value)
+	eventType = strings.Replace(eventType, "*", "", len(eventType))
+	var fragments = strings.Split(eventType, ".")
+	if len(fragments) > 2 {
+		fragments = fragments[len(fragments)-2:]
+	}
+	return strings.Join(fragments, "_")
+}
+
+//RegisterType registers event value types, so that events decoded from their type name and JSON value can be rendered like local events, the first registered type wins
+func RegisterType(values ...interface{}) {
+	registry.mux.Lock()
+	defer registry.mux.Unlock()
+	for _, value := range values {
+		name := TypeName(value)
+		if _, has := registry.types[name]; has {
+			continue
+		}
+		valueType := reflect.TypeOf(value)
+		for valueType.Kind() == reflect.Ptr {
+			valueType = valueType.Elem()
+		}
+		registry.types[name] = valueType
+	}
+}
+
+//NewValue returns pointer to a new value of registered event type
+func NewValue(typeName string) (interface{}, bool) {
+	registry.mux.RLock()
+	defer registry.mux.RUnlock()
+	valueType, ok := registry.types[typeName]
+	if !ok {
+		return nil, false
+	}
+	return reflect.New(valueType).Interface(), true
+}
+
+//NewEventAt creates a new event with supplied timestamp and init event
+func NewEventAt(value interface{}, timestamp time.Time, init Event) Event {
+	return &event{
+		init:      init,
+		timestamp: timestamp,
+		value:     value,
+	}
+}
+
+func init() {
+	RegisterType(&ErrorEvent{}, &ResetError{}, &RepeatedEvent{}, &SleepEvent{}, &OutputEvent{}, &StdoutEvent{})
+}
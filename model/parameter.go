@@ -0,0 +1,166 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"sort"
+	"strings"
+)
+
+const (
+	//ParameterTypeAny represents parameter accepting any value
+	ParameterTypeAny = "any"
+	//ParameterTypeString represents text parameter
+	ParameterTypeString = "string"
+	//ParameterTypeInt represents integer parameter
+	ParameterTypeInt = "int"
+	//ParameterTypeFloat represents floating point parameter
+	ParameterTypeFloat = "float"
+	//ParameterTypeBool represents boolean parameter
+	ParameterTypeBool = "bool"
+	//ParameterTypeMap represents map parameter, JSON object text is decoded
+	ParameterTypeMap = "map"
+	//ParameterTypeSlice represents slice parameter, JSON array or comma separated text is decoded
+	ParameterTypeSlice = "slice"
+)
+
+var parameterTypes = []string{ParameterTypeAny, ParameterTypeString, ParameterTypeInt, ParameterTypeFloat, ParameterTypeBool, ParameterTypeMap, ParameterTypeSlice}
+
+//Parameter represents workflow parameter declaration
+type Parameter struct {
+	Name        string
+	Type        string        `description:"parameter type: any, string, int, float, bool, map or slice, any by default"`
+	Required    bool          `description:"flag to fail workflow run if parameter was not supplied and has no default"`
+	Default     interface{}   `description:"value used when parameter was not supplied, $expression is expanded with context state"`
+	Enum        []interface{} `description:"allowed values"`
+	Description string
+}
+
+//Validate checks if parameter declaration is valid
+func (p *Parameter) Validate() error {
+	if p.Name == "" {
+		return errors.New("parameter name was empty")
+	}
+	if p.Type != "" && !toolbox.HasSliceAnyElements(parameterTypes, p.Type) {
+		return fmt.Errorf("unsupported parameter %v type: %v, supported: %v", p.Name, p.Type, strings.Join(parameterTypes, ", "))
+	}
+	return nil
+}
+
+//Convert converts supplied value to declared parameter type and checks allowed values
+func (p *Parameter) Convert(value interface{}) (interface{}, error) {
+	var result = value
+	var err error
+	switch p.Type {
+	case ParameterTypeString:
+		result = toolbox.AsString(value)
+	case ParameterTypeInt:
+		result, err = toolbox.ToInt(value)
+	case ParameterTypeFloat:
+		result, err = toolbox.ToFloat(value)
+	case ParameterTypeBool:
+		result, err = toolbox.ToBoolean(value)
+	case ParameterTypeMap:
+		if text, ok := value.(string); ok {
+			result, err = toolbox.JSONToMap(text)
+		}
+		if err == nil && !toolbox.IsMap(result) {
+			err = errors.New("not a map")
+		}
+	case ParameterTypeSlice:
+		if text, ok := value.(string); ok {
+			if result, err = toolbox.JSONToSlice(text); err != nil {
+				result, err = toolbox.AsSlice(strings.Split(text, ",")), nil
+			}
+		}
+		if err == nil && !toolbox.IsSlice(result) {
+			err = errors.New("not a slice")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: expected %v, but had %v", p.Name, p.Type, value)
+	}
+	if len(p.Enum) > 0 && !p.isAllowed(result) {
+		return nil, fmt.Errorf("%v: %v is not one of %v", p.Name, result, p.Enum)
+	}
+	return result, nil
+}
+
+func (p *Parameter) isAllowed(value interface{}) bool {
+	for _, candidate := range p.Enum {
+		if toolbox.AsString(candidate) == toolbox.AsString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+//String returns parameter signature with its description
+func (p *Parameter) String() string {
+	var attributes = []string{p.Type}
+	if p.Type == "" {
+		attributes[0] = ParameterTypeAny
+	}
+	if p.Required {
+		attributes = append(attributes, "required")
+	}
+	if p.Default != nil {
+		attributes = append(attributes, fmt.Sprintf("default: %v", p.Default))
+	}
+	if len(p.Enum) > 0 {
+		attributes = append(attributes, fmt.Sprintf("enum: %v", p.Enum))
+	}
+	var result = fmt.Sprintf("%v (%v)", p.Name, strings.Join(attributes, ", "))
+	if p.Description != "" {
+		result += ": " + p.Description
+	}
+	return result
+}
+
+//Parameters represents workflow parameter declarations
+type Parameters []*Parameter
+
+//Validate checks if parameter declarations are valid
+func (p Parameters) Validate() error {
+	var names = make(map[string]bool)
+	for _, parameter := range p {
+		if err := parameter.Validate(); err != nil {
+			return err
+		}
+		if names[parameter.Name] {
+			return fmt.Errorf("duplicate parameter: %v", parameter.Name)
+		}
+		names[parameter.Name] = true
+	}
+	return nil
+}
+
+//Apply sets defaults, converts declared parameters and validates required and allowed values, it returns error listing all problems
+func (p Parameters) Apply(params, state data.Map) error {
+	var problems = make([]string, 0)
+	for _, parameter := range p {
+		value, has := params[parameter.Name]
+		if !has || value == nil {
+			if parameter.Default == nil {
+				if parameter.Required {
+					problems = append(problems, fmt.Sprintf("%v: was required", parameter.Name))
+				}
+				continue
+			}
+			value = state.Expand(parameter.Default)
+		}
+		converted, err := parameter.Convert(value)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		params[parameter.Name] = converted
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
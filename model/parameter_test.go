@@ -0,0 +1,43 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/data"
+	"testing"
+)
+
+func TestParameters_Apply(t *testing.T) {
+	var parameters = Parameters{
+		{Name: "env", Type: ParameterTypeString, Required: true, Enum: []interface{}{"dev", "qa"}},
+		{Name: "port", Type: ParameterTypeInt, Default: "$defaultPort"},
+		{Name: "tags", Type: ParameterTypeSlice},
+		{Name: "config", Type: ParameterTypeMap},
+		{Name: "ratio", Type: ParameterTypeFloat},
+	}
+	if !assert.Nil(t, parameters.Validate()) {
+		return
+	}
+	var state = data.NewMap()
+	state.Put("defaultPort", 8080)
+
+	params := data.Map{"env": "dev", "tags": `["a","b"]`, "config": `{"k":1}`, "ratio": "0.5"}
+	if assert.Nil(t, parameters.Apply(params, state)) {
+		assert.EqualValues(t, 8080, params["port"])
+		assert.EqualValues(t, []interface{}{"a", "b"}, params["tags"])
+		assert.EqualValues(t, map[string]interface{}{"k": 1.0}, params["config"])
+		assert.EqualValues(t, 0.5, params["ratio"])
+	}
+
+	params = data.Map{"tags": "a,b", "config": "abc", "ratio": "x"}
+	err := parameters.Apply(params, state)
+	if assert.NotNil(t, err) {
+		assert.EqualValues(t, "config: expected map, but had abc; env: was required; ratio: expected float, but had x", err.Error())
+	}
+	assert.EqualValues(t, []interface{}{"a", "b"}, params["tags"])
+}
+
+func TestParameters_Validate(t *testing.T) {
+	assert.NotNil(t, Parameters{{Name: "a", Type: "date"}}.Validate())
+	assert.NotNil(t, Parameters{{Name: "a"}, {Name: "a"}}.Validate())
+	assert.NotNil(t, Parameters{{Type: ParameterTypeInt}}.Validate())
+}
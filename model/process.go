@@ -21,6 +21,8 @@ type Process struct {
 	State      data.Map
 	Terminated int32
 	Scheduled  *Task
+	Checkpoint *Checkpoint
+	DryRun     bool
 	*ExecutionError
 }
 
@@ -63,6 +65,15 @@ func (p *Process) AddTagIDs(tagIDs ...string) {
 	}
 }
 
+//Fork creates a process sharing workflow, state and checkpoint with this process, it is used to run tasks concurrently
+func (p *Process) Fork() *Process {
+	var result = NewProcess(p.Source, p.Workflow, p)
+	result.State = p.State
+	result.Checkpoint = p.Checkpoint
+	result.TaskNode = p.TaskNode
+	return result
+}
+
 //NewProcess creates a new workflow, pipeline process
 func NewProcess(source *url.Resource, workflow *Workflow, upstream *Process) *Process {
 	var process = &Process{
@@ -70,11 +81,15 @@ func NewProcess(source *url.Resource, workflow *Workflow, upstream *Process) *Pr
 		ExecutionError: &ExecutionError{},
 		Workflow:       workflow,
 		Activities:     NewActivities(),
+		State:          data.NewMap(),
 	}
 	if source != nil {
 		_, process.Owner = toolbox.URLSplit(source.URL)
 	}
 	process.TagIDs = map[string]bool{}
+	if upstream != nil {
+		process.DryRun = upstream.DryRun
+	}
 	if upstream != nil && len(upstream.TagIDs) > 0 {
 		for k := range upstream.TagIDs {
 			process.TagIDs[k] = true
@@ -118,6 +133,13 @@ func (p *Processes) Last() *Process {
 	return nil
 }
 
+//Len returns number of processes in the stack.
+func (p *Processes) Len() int {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	return len(p.processes)
+}
+
 //Recent returns the most reset process.
 func (p *Processes) Recent(count int) []*Process {
 	p.mux.RLock()
@@ -177,6 +199,7 @@ func NewProcesses() *Processes {
 //Error represent workflow error
 type ExecutionError struct {
 	Error    string
+	Timeout  bool
 	Caller   string
 	TaskName string
 	Request  interface{}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/criteria"
+	"math"
+	"math/rand"
+	"regexp"
+	"time"
+)
+
+//Retry represents action retry policy with backoff, unlike Repeater it handles service errors
+type Retry struct {
+	MaxAttempts int     `description:"max number of attempts, including the first one"`
+	DelayMs     int     `description:"initial backoff delay in ms"`
+	MaxDelayMs  int     `description:"max backoff delay in ms"`
+	Multiplier  float64 `description:"backoff delay multiplier, 1 for a fixed delay, default 2"`
+	Jitter      float64 `description:"random backoff delay ratio between 0 and 1"`
+	OnError     string  `description:"regular expression matching error to retry, any error is retried if empty"`
+	When        string  `description:"retry criteria, evaluated with $error and $attempt"`
+	DeadlineMs  int     `description:"total retry deadline in ms"`
+	onError     *regexp.Regexp
+}
+
+//Init initialises retry policy
+func (r *Retry) Init() error {
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = 1
+	}
+	if r.Multiplier == 0 {
+		r.Multiplier = 2
+	}
+	if r.OnError != "" && r.onError == nil {
+		expr, err := regexp.Compile(r.OnError)
+		if err != nil {
+			return fmt.Errorf("invalid retry onError expression: %v, %v", r.OnError, err)
+		}
+		r.onError = expr
+	}
+	return r.Validate()
+}
+
+//Validate checks if retry policy is valid
+func (r *Retry) Validate() error {
+	if r.MaxAttempts < 0 {
+		return errors.New("retry maxAttempts was negative")
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return fmt.Errorf("invalid retry jitter: %v, expected value between 0 and 1", r.Jitter)
+	}
+	return nil
+}
+
+//Delay returns backoff delay after supplied failed attempt (starting from 1)
+func (r *Retry) Delay(attempt int) time.Duration {
+	delay := float64(r.DelayMs)
+	multiplier := r.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	if attempt > 1 {
+		delay *= math.Pow(multiplier, float64(attempt-1))
+	}
+	if r.MaxDelayMs > 0 && delay > float64(r.MaxDelayMs) {
+		delay = float64(r.MaxDelayMs)
+	}
+	if r.Jitter > 0 {
+		delay += delay * r.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay) * time.Millisecond
+}
+
+//CanRetry returns true if supplied failed attempt can be retried, elapsed includes upcoming backoff delay
+func (r *Retry) CanRetry(context *endly.Context, attempt int, elapsed time.Duration, err error) (bool, error) {
+	if err == nil || attempt >= r.MaxAttempts {
+		return false, nil
+	}
+	if r.DeadlineMs > 0 && elapsed > time.Duration(r.DeadlineMs)*time.Millisecond {
+		return false, nil
+	}
+	if r.onError != nil && !r.onError.MatchString(err.Error()) {
+		return false, nil
+	}
+	if r.When == "" {
+		return true, nil
+	}
+	var state = context.State()
+	state = state.Clone()
+	state.Put("error", err.Error())
+	state.Put("attempt", attempt)
+	canRetry, criteriaErr := criteria.Evaluate(context, state, r.When, "Retry.When", true)
+	if criteriaErr != nil {
+		return false, fmt.Errorf("failed to check retry criteria: %v", criteriaErr)
+	}
+	return canRetry, nil
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"testing"
+	"time"
+)
+
+func TestRetry_Delay(t *testing.T) {
+	retry := &Retry{DelayMs: 100, MaxDelayMs: 500}
+	assert.Nil(t, retry.Init())
+	assert.EqualValues(t, 100*time.Millisecond, retry.Delay(1))
+	assert.EqualValues(t, 200*time.Millisecond, retry.Delay(2))
+	assert.EqualValues(t, 400*time.Millisecond, retry.Delay(3))
+	assert.EqualValues(t, 500*time.Millisecond, retry.Delay(4))
+
+	retry = &Retry{DelayMs: 100, Jitter: 0.5}
+	assert.Nil(t, retry.Init())
+	for i := 0; i < 10; i++ {
+		delay := retry.Delay(1)
+		assert.True(t, delay >= 50*time.Millisecond && delay <= 150*time.Millisecond, delay)
+	}
+	assert.NotNil(t, (&Retry{Jitter: 2}).Init())
+	assert.NotNil(t, (&Retry{OnError: "("}).Init())
+}
+
+func TestRetry_CanRetry(t *testing.T) {
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	var useCases = []struct {
+		description string
+		retry       *Retry
+		attempt     int
+		elapsed     time.Duration
+		err         error
+		expect      bool
+	}{
+		{
+			description: "no error",
+			retry:       &Retry{MaxAttempts: 3},
+			attempt:     1,
+		},
+		{
+			description: "attempt left",
+			retry:       &Retry{MaxAttempts: 3},
+			attempt:     2,
+			err:         errors.New("connection refused"),
+			expect:      true,
+		},
+		{
+			description: "attempts exhausted",
+			retry:       &Retry{MaxAttempts: 3},
+			attempt:     3,
+			err:         errors.New("connection refused"),
+		},
+		{
+			description: "deadline exceeded",
+			retry:       &Retry{MaxAttempts: 3, DeadlineMs: 100},
+			attempt:     1,
+			elapsed:     200 * time.Millisecond,
+			err:         errors.New("connection refused"),
+		},
+		{
+			description: "error matched",
+			retry:       &Retry{MaxAttempts: 3, OnError: "refused|timeout"},
+			attempt:     1,
+			err:         errors.New("connection refused"),
+			expect:      true,
+		},
+		{
+			description: "error not matched",
+			retry:       &Retry{MaxAttempts: 3, OnError: "timeout"},
+			attempt:     1,
+			err:         errors.New("connection refused"),
+		},
+		{
+			description: "criteria met",
+			retry:       &Retry{MaxAttempts: 3, When: "$attempt < 2"},
+			attempt:     1,
+			err:         errors.New("connection refused"),
+			expect:      true,
+		},
+		{
+			description: "criteria not met",
+			retry:       &Retry{MaxAttempts: 3, When: "$error:/timeout/"},
+			attempt:     1,
+			err:         errors.New("connection refused"),
+		},
+	}
+	for _, useCase := range useCases {
+		assert.Nil(t, useCase.retry.Init(), useCase.description)
+		actual, err := useCase.retry.CanRetry(context, useCase.attempt, useCase.elapsed, useCase.err)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
@@ -5,7 +5,8 @@ type Task struct {
 	*AbstractNode
 	Actions []*Action //actions
 	*TasksNode
-	Fail bool //controls if return fail status workflow on catch task
+	Fail      bool     //controls if return fail status workflow on catch task
+	DependsOn []string `description:"sibling task names that have to complete before this task runs, tasks with dependencies run concurrently as a graph"`
 
 	//internal only for inline workflow meta data
 
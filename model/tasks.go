@@ -7,9 +7,10 @@ import (
 
 //TasksNode represents a task node
 type TasksNode struct {
-	Tasks        []*Task //sub tasks
-	OnErrorTask  string  //task that will run if error occur, the final workflow will return this task response
-	DeferredTask string  //task that will always run if there has been previous  error or not
+	Tasks          []*Task //sub tasks
+	OnErrorTask    string  //task that will run if error occur, the final workflow will return this task response
+	DeferredTask   string  //task that will always run if there has been previous  error or not
+	MaxParallelism int     `description:"max number of tasks running concurrently when tasks declare DependsOn, 0 - no limit"`
 }
 
 //Select selects tasks matching supplied selector
@@ -22,9 +23,10 @@ func (t *TasksNode) Select(selector TasksSelector) *TasksNode {
 		allowed[task] = true
 	}
 	var result = &TasksNode{
-		OnErrorTask:  t.OnErrorTask,
-		DeferredTask: t.DeferredTask,
-		Tasks:        []*Task{},
+		OnErrorTask:    t.OnErrorTask,
+		DeferredTask:   t.DeferredTask,
+		MaxParallelism: t.MaxParallelism,
+		Tasks:          []*Task{},
 	}
 
 	if result.DeferredTask != "" {
@@ -79,3 +81,64 @@ func (t *TasksNode) Has(name string) bool {
 	_, err := t.Task(name)
 	return err == nil
 }
+
+//HasDependencies returns true if any of node tasks declares dependencies
+func (t *TasksNode) HasDependencies() bool {
+	for _, task := range t.Tasks {
+		if len(task.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//ValidateDependencies checks if task dependencies reference sibling tasks and do not form a cycle
+func (t *TasksNode) ValidateDependencies() error {
+	var tasks = make(map[string]*Task)
+	for _, task := range t.Tasks {
+		tasks[task.Name] = task
+	}
+	for _, task := range t.Tasks {
+		for _, dependency := range task.DependsOn {
+			if _, ok := tasks[dependency]; !ok {
+				return fmt.Errorf("task %v depends on unknown task: %v", task.Name, dependency)
+			}
+			if dependency == t.OnErrorTask || dependency == t.DeferredTask {
+				return fmt.Errorf("task %v can not depend on onError/deferred task: %v", task.Name, dependency)
+			}
+		}
+	}
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	var status = make(map[string]int)
+	var visit func(task *Task, path []string) error
+	visit = func(task *Task, path []string) error {
+		switch status[task.Name] {
+		case visiting:
+			return fmt.Errorf("task dependency cycle: %v", strings.Join(append(path, task.Name), " -> "))
+		case visited:
+			return nil
+		}
+		status[task.Name] = visiting
+		for _, dependency := range task.DependsOn {
+			if err := visit(tasks[dependency], append(path, task.Name)); err != nil {
+				return err
+			}
+		}
+		status[task.Name] = visited
+		return nil
+	}
+	for _, task := range t.Tasks {
+		if err := visit(task, []string{}); err != nil {
+			return err
+		}
+		if task.TasksNode != nil {
+			if err := task.TasksNode.ValidateDependencies(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
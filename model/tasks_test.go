@@ -0,0 +1,62 @@
+package model
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func newDependentTask(name string, dependsOn ...string) *Task {
+	return &Task{AbstractNode: &AbstractNode{Name: name}, DependsOn: dependsOn}
+}
+
+func TestTasksNode_ValidateDependencies(t *testing.T) {
+	var useCases = []struct {
+		description string
+		tasks       *TasksNode
+		error       string
+	}{
+		{
+			description: "valid dependencies",
+			tasks: &TasksNode{Tasks: []*Task{
+				newDependentTask("a"),
+				newDependentTask("b", "a"),
+				newDependentTask("c", "a", "b"),
+			}},
+		},
+		{
+			description: "unknown dependency",
+			tasks: &TasksNode{Tasks: []*Task{
+				newDependentTask("a", "x"),
+			}},
+			error: "unknown task: x",
+		},
+		{
+			description: "onError dependency",
+			tasks: &TasksNode{OnErrorTask: "catch", Tasks: []*Task{
+				newDependentTask("a", "catch"),
+				newDependentTask("catch"),
+			}},
+			error: "can not depend on onError/deferred task",
+		},
+		{
+			description: "dependency cycle",
+			tasks: &TasksNode{Tasks: []*Task{
+				newDependentTask("a", "c"),
+				newDependentTask("b", "a"),
+				newDependentTask("c", "b"),
+			}},
+			error: "task dependency cycle: a -> c -> b -> a",
+		},
+	}
+	for _, useCase := range useCases {
+		err := useCase.tasks.ValidateDependencies()
+		if useCase.error == "" {
+			assert.Nil(t, err, useCase.description)
+			continue
+		}
+		if assert.NotNil(t, err, useCase.description) {
+			assert.True(t, strings.Contains(err.Error(), useCase.error), useCase.description+": "+err.Error())
+		}
+	}
+}
@@ -8,8 +8,9 @@ import (
 
 //Workflow represents a workflow
 type Workflow struct {
-	Source *url.Resource //source definition of the workflow
-	Data   data.Map      //workflow data
+	Source     *url.Resource //source definition of the workflow
+	Data       data.Map      //workflow data
+	Parameters Parameters    `description:"workflow parameters schema, validated before run"`
 	*AbstractNode
 	*TasksNode //workflow tasks
 }
@@ -32,6 +33,9 @@ func (w *Workflow) Validate() error {
 	if len(w.Tasks) == 0 {
 		return errors.New("tasks were empty")
 	}
+	if err := w.Parameters.Validate(); err != nil {
+		return err
+	}
 	if w.DeferredTask != "" {
 		if _, err := w.Task(w.DeferredTask); err != nil {
 			return err
@@ -42,6 +46,5 @@ func (w *Workflow) Validate() error {
 			return err
 		}
 	}
-
-	return nil
+	return w.ValidateDependencies()
 }
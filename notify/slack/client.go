@@ -10,6 +10,7 @@ func getClient(context *endly.Context, credentials string) (*slack.Client, error
 	if err != nil {
 		return nil, err
 	}
+	context.Redactor().AddSecretFields(credConfig)
 	api := slack.New(credConfig.Password)
 	return api, nil
 }
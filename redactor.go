@@ -0,0 +1,263 @@
+package endly
+
+import (
+	"github.com/viant/endly/model/msg"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	//SecretRevealEnvKey env key name to disable secret masking for troubleshooting, export ENDLY_SECRET_REVEAL=true
+	SecretRevealEnvKey = "ENDLY_SECRET_REVEAL"
+	//RedactedMask replaces revealed secret values in published events
+	RedactedMask = "***"
+	//minSecretLength represents minimal tracked secret length, shorter values would mask unrelated output
+	minSecretLength = 4
+	//maxRedactionDepth limits nested value traversal
+	maxRedactionDepth = 32
+)
+
+//secretFieldFragments represents lower case fragments of struct field or map key names holding secrets
+var secretFieldFragments = []string{"password", "secret", "token", "privatekey", "private_key"}
+
+//Redactor tracks revealed secret values and masks them in published events
+type Redactor struct {
+	secrets  map[string]bool
+	replacer *strings.Replacer
+	mux      sync.RWMutex
+}
+
+//Add tracks supplied secret values, values shorter than 4 characters are ignored
+func (r *Redactor) Add(secrets ...string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	var added = false
+	for _, secret := range secrets {
+		if len(secret) < minSecretLength || r.secrets[secret] {
+			continue
+		}
+		r.secrets[secret] = true
+		added = true
+	}
+	if !added {
+		return
+	}
+	var values = make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		values = append(values, secret)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	var pairs = make([]string, 0, 2*len(values))
+	for _, secret := range values {
+		pairs = append(pairs, secret, RedactedMask)
+	}
+	r.replacer = strings.NewReplacer(pairs...)
+}
+
+//AddSecretFields tracks string values of struct fields or map keys named like password, secret, token or private key, i.e. credentials config
+func (r *Redactor) AddSecretFields(source interface{}) {
+	if source == nil {
+		return
+	}
+	var secrets = make([]string, 0)
+	collectSecretFields(reflect.ValueOf(source), false, &secrets, 0)
+	r.Add(secrets...)
+}
+
+func collectSecretFields(value reflect.Value, isSecret bool, secrets *[]string, depth int) {
+	if depth > maxRedactionDepth {
+		return
+	}
+	switch value.Kind() {
+	case reflect.String:
+		if isSecret {
+			*secrets = append(*secrets, value.String())
+		}
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			collectSecretFields(value.Elem(), isSecret, secrets, depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Type().Field(i); field.PkgPath == "" {
+				collectSecretFields(value.Field(i), isSecret || isSecretField(field.Name), secrets, depth+1)
+			}
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			if key.Kind() == reflect.String {
+				collectSecretFields(value.MapIndex(key), isSecret || isSecretField(key.String()), secrets, depth+1)
+			}
+		}
+	}
+}
+
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range secretFieldFragments {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+//Redact masks tracked secrets in supplied text
+func (r *Redactor) Redact(text string) string {
+	r.mux.RLock()
+	replacer := r.replacer
+	r.mux.RUnlock()
+	if replacer == nil || os.Getenv(SecretRevealEnvKey) == "true" {
+		return text
+	}
+	return replacer.Replace(text)
+}
+
+//RedactValue returns a copy of supplied value with tracked secrets masked, original value is returned if it has no secrets
+func (r *Redactor) RedactValue(source interface{}) interface{} {
+	result, _ := r.redact(source)
+	return result
+}
+
+//RedactEvent returns event with redacted value, original event is returned if its value has no secrets
+func (r *Redactor) RedactEvent(event msg.Event) msg.Event {
+	if event == nil {
+		return event
+	}
+	value, ok := r.redact(event.Value())
+	if !ok {
+		return event
+	}
+	result := msg.NewEventAt(value, event.Timestamp(), event.Init())
+	result.SetLoggable(event.IsLoggable())
+	return result
+}
+
+func (r *Redactor) redact(source interface{}) (interface{}, bool) {
+	r.mux.RLock()
+	replacer := r.replacer
+	r.mux.RUnlock()
+	if replacer == nil || source == nil || os.Getenv(SecretRevealEnvKey) == "true" {
+		return source, false
+	}
+	if redacted, ok := redactValue(replacer, reflect.ValueOf(source), 0); ok {
+		return redacted.Interface(), true
+	}
+	return source, false
+}
+
+//redactValue returns masked copy of supplied value and true if any secret was found, unexported fields are shared with the original value
+func redactValue(replacer *strings.Replacer, value reflect.Value, depth int) (reflect.Value, bool) {
+	if depth > maxRedactionDepth || !value.IsValid() {
+		return value, false
+	}
+	switch value.Kind() {
+	case reflect.String:
+		text := value.String()
+		redacted := replacer.Replace(text)
+		if redacted == text {
+			return value, false
+		}
+		result := reflect.New(value.Type()).Elem()
+		result.SetString(redacted)
+		return result, true
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value, false
+		}
+		elem, ok := redactValue(replacer, value.Elem(), depth+1)
+		if !ok {
+			return value, false
+		}
+		result := reflect.New(elem.Type())
+		result.Elem().Set(elem)
+		return result, true
+	case reflect.Interface:
+		if value.IsNil() {
+			return value, false
+		}
+		elem, ok := redactValue(replacer, value.Elem(), depth+1)
+		if !ok {
+			return value, false
+		}
+		result := reflect.New(value.Type()).Elem()
+		result.Set(elem)
+		return result, true
+	case reflect.Struct:
+		var result reflect.Value
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			field, ok := redactValue(replacer, value.Field(i), depth+1)
+			if !ok {
+				continue
+			}
+			if !result.IsValid() {
+				result = reflect.New(value.Type()).Elem()
+				result.Set(value)
+			}
+			result.Field(i).Set(field)
+		}
+		return result, result.IsValid()
+	case reflect.Slice:
+		if value.IsNil() {
+			return value, false
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			text := string(value.Bytes())
+			redacted := replacer.Replace(text)
+			if redacted == text {
+				return value, false
+			}
+			return reflect.ValueOf([]byte(redacted)).Convert(value.Type()), true
+		}
+		var result reflect.Value
+		for i := 0; i < value.Len(); i++ {
+			item, ok := redactValue(replacer, value.Index(i), depth+1)
+			if !ok {
+				continue
+			}
+			if !result.IsValid() {
+				result = reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+				reflect.Copy(result, value)
+			}
+			result.Index(i).Set(item)
+		}
+		return result, result.IsValid()
+	case reflect.Map:
+		if value.IsNil() {
+			return value, false
+		}
+		var redacted = make(map[int]reflect.Value)
+		keys := value.MapKeys()
+		for i, key := range keys {
+			if item, ok := redactValue(replacer, value.MapIndex(key), depth+1); ok {
+				redacted[i] = item
+			}
+		}
+		if len(redacted) == 0 {
+			return value, false
+		}
+		result := reflect.MakeMapWithSize(value.Type(), len(keys))
+		for i, key := range keys {
+			item, ok := redacted[i]
+			if !ok {
+				item = value.MapIndex(key)
+			}
+			result.SetMapIndex(key, item)
+		}
+		return result, true
+	}
+	return value, false
+}
+
+//NewRedactor creates a new secret redactor
+func NewRedactor() *Redactor {
+	return &Redactor{secrets: make(map[string]bool)}
+}
@@ -0,0 +1,96 @@
+package endly_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/secret"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+type redactedOutput struct {
+	Command string
+	Stdout  []byte
+	Data    map[string]interface{}
+	Lines   []string
+	Next    *redactedOutput
+	cause   string
+}
+
+func TestRedactor_RedactValue(t *testing.T) {
+	redactor := endly.NewRedactor()
+	redactor.Add("abc", "dbPass123", "dbPass123Long")
+	redactor.AddSecretFields(map[string]interface{}{"Username": "admin", "Password": "s3cretValue", "Private_Key": "KEYDATA"})
+
+	source := &redactedOutput{
+		Command: "mysql -uadmin -pdbPass123 abc",
+		Stdout:  []byte("token: dbPass123Long"),
+		Data:    map[string]interface{}{"password": "s3cretValue", "count": 1},
+		Lines:   []string{"ok", "KEYDATA"},
+		Next:    &redactedOutput{Command: "ls"},
+		cause:   "dbPass123",
+	}
+	redacted, ok := redactor.RedactValue(source).(*redactedOutput)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.EqualValues(t, "mysql -uadmin -p*** abc", redacted.Command)
+	assert.EqualValues(t, "token: ***", string(redacted.Stdout))
+	assert.EqualValues(t, map[string]interface{}{"password": "***", "count": 1}, redacted.Data)
+	assert.EqualValues(t, []string{"ok", "***"}, redacted.Lines)
+	assert.True(t, source.Next == redacted.Next, "unchanged pointers should be shared")
+	assert.EqualValues(t, "dbPass123", redacted.cause)
+
+	assert.EqualValues(t, "mysql -uadmin -pdbPass123 abc", source.Command, "source should not be modified")
+	assert.EqualValues(t, "s3cretValue", source.Data["password"])
+
+	clean := &redactedOutput{Command: "ls -la"}
+	assert.True(t, clean == redactor.RedactValue(clean))
+	assert.EqualValues(t, "admin ***", redactor.Redact("admin s3cretValue"))
+
+	_ = os.Setenv(endly.SecretRevealEnvKey, "true")
+	assert.EqualValues(t, "admin s3cretValue", redactor.Redact("admin s3cretValue"))
+	_ = os.Unsetenv(endly.SecretRevealEnvKey)
+}
+
+func TestContext_Publish_Redaction(t *testing.T) {
+	directory, err := ioutil.TempDir("", "redactor")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(directory)
+	err = ioutil.WriteFile(path.Join(directory, "db.json"), []byte(`{"Username":"tester","Password":"p@ssw0rd!"}`), 0600)
+	if !assert.Nil(t, err) {
+		return
+	}
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	context.Secrets = secret.New(directory, false)
+
+	var events = make([]msg.Event, 0)
+	context.SetListener(func(event msg.Event) {
+		events = append(events, event)
+	})
+	state := context.State()
+	state.Put("dbSecrets", state.Expand("${secrets.db}"))
+	command := state.ExpandAsText("mysql -u${dbSecrets.Username} -p${dbSecrets.Password}")
+	assert.EqualValues(t, "mysql -utester -pp@ssw0rd!", command)
+
+	output := &msg.StdoutEvent{Stdout: "connected with p@ssw0rd!"}
+	start := context.Publish(output)
+	cloned := context.Clone()
+	cloned.PublishWithStartEvent(msg.NewErrorEvent("failed: "+command), start)
+
+	if !assert.EqualValues(t, 2, len(events)) {
+		return
+	}
+	assert.EqualValues(t, "connected with ***", events[0].Value().(*msg.StdoutEvent).Stdout)
+	assert.EqualValues(t, "connected with p@ssw0rd!", output.Stdout)
+	assert.True(t, events[0] == start)
+	assert.True(t, events[1].Init() == start)
+	assert.EqualValues(t, "failed: mysql -utester -p***", events[1].Value().(*msg.ErrorEvent).Error)
+}
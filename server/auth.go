@@ -0,0 +1,85 @@
+package server
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//authError represents authentication or authorization error with HTTP status code
+type authError struct {
+	status  int
+	message string
+}
+
+func (e *authError) Error() string {
+	return e.message
+}
+
+//bearerToken returns bearer token from Authorization header
+func bearerToken(httpRequest *http.Request) string {
+	const prefix = "bearer "
+	header := httpRequest.Header.Get("Authorization")
+	if len(header) <= len(prefix) || strings.ToLower(string(header[:len(prefix)])) != prefix {
+		return ""
+	}
+	return strings.TrimSpace(string(header[len(prefix):]))
+}
+
+//authenticate returns credential matching bearer token or verified client certificate, nil credential is returned if server has no credentials configured
+func (s *Server) authenticate(httpRequest *http.Request) (*Credential, error) {
+	if len(s.config.Credentials) == 0 {
+		return nil, nil
+	}
+	if token := bearerToken(httpRequest); token != "" {
+		for _, credential := range s.config.Credentials {
+			if credential.Token != "" && subtle.ConstantTimeCompare([]byte(credential.Token), []byte(token)) == 1 {
+				return credential, nil
+			}
+		}
+		return nil, &authError{status: http.StatusUnauthorized, message: "invalid bearer token"}
+	}
+	if httpRequest.TLS != nil && len(httpRequest.TLS.VerifiedChains) > 0 && len(httpRequest.TLS.VerifiedChains[0]) > 0 {
+		commonName := httpRequest.TLS.VerifiedChains[0][0].Subject.CommonName
+		for _, credential := range s.config.Credentials {
+			if credential.CommonName != "" && credential.CommonName == commonName {
+				return credential, nil
+			}
+		}
+		return nil, &authError{status: http.StatusUnauthorized, message: fmt.Sprintf("unknown client certificate: %v", commonName)}
+	}
+	return nil, &authError{status: http.StatusUnauthorized, message: "authentication required"}
+}
+
+//authorize authenticates http request and checks if credential allows supplied service action
+func (s *Server) authorize(httpRequest *http.Request, service, action string) (*Credential, error) {
+	credential, err := s.authenticate(httpRequest)
+	if err != nil || credential == nil {
+		return nil, err
+	}
+	if err = credential.authorizeAction(service, action); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+//authorizeAction returns an error if credential does not allow supplied service action
+func (c *Credential) authorizeAction(service, action string) error {
+	if !c.IsAllowed(service, action) {
+		return &authError{status: http.StatusForbidden, message: fmt.Sprintf("%v is not allowed to run %v:%v", c.Name, service, action)}
+	}
+	return nil
+}
+
+//authorizeJob authenticates http request and checks if job was submitted by the same credential
+func (s *Server) authorizeJob(httpRequest *http.Request, job *Job) error {
+	credential, err := s.authenticate(httpRequest)
+	if err != nil || credential == nil {
+		return err
+	}
+	if job.owner != credential.Name {
+		return &authError{status: http.StatusForbidden, message: fmt.Sprintf("%v is not allowed to access job: %v", credential.Name, job.ID)}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/meta"
+	"github.com/viant/endly/workflow"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCredential_IsAllowed(t *testing.T) {
+	var useCases = []struct {
+		description string
+		allow       []string
+		service     string
+		action      string
+		expect      bool
+	}{
+		{description: "any", allow: []string{"*"}, service: "exec", action: "run", expect: true},
+		{description: "exact", allow: []string{"workflow:run"}, service: "workflow", action: "run", expect: true},
+		{description: "other action", allow: []string{"workflow:run"}, service: "workflow", action: "print", expect: false},
+		{description: "any action", allow: []string{"http/runner:*"}, service: "http/runner", action: "send", expect: true},
+		{description: "any service", allow: []string{"*:nop"}, service: "nop", action: "nop", expect: true},
+		{description: "service only", allow: []string{"nop"}, service: "nop", action: "fail", expect: true},
+		{description: "not allowed", allow: []string{"nop:*"}, service: "exec", action: "run", expect: false},
+		{description: "empty", service: "exec", action: "run", expect: false},
+	}
+	for _, useCase := range useCases {
+		credential := &Credential{Name: "ci", Allow: useCase.allow}
+		assert.Equal(t, useCase.expect, credential.IsAllowed(useCase.service, useCase.action), useCase.description)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NotNil(t, (&Config{}).Validate())
+	assert.NotNil(t, (&Config{Port: "8080", TLS: &TLSConfig{CertFile: "cert.pem"}}).Validate())
+	assert.NotNil(t, (&Config{Port: "8080", Credentials: []*Credential{{Name: "ci"}}}).Validate())
+	assert.NotNil(t, (&Config{Port: "8080", Credentials: []*Credential{{Name: "ci", CommonName: "ci"}}}).Validate())
+	assert.Nil(t, (&Config{Port: "8080", Credentials: []*Credential{{Name: "ci", Token: "abc"}}}).Validate())
+}
+
+func TestNewConfigFromURL(t *testing.T) {
+	config, err := NewConfigFromURL("test/config.yaml")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "8071", config.Port)
+	assert.Equal(t, "/etc/endly/ca.pem", config.TLS.ClientCAFile)
+	if assert.Equal(t, 2, len(config.Credentials)) {
+		assert.Equal(t, "dev-token", config.Credentials[0].Token)
+		assert.True(t, config.Credentials[0].IsAllowed("http/runner", "send"))
+		assert.False(t, config.Credentials[0].IsAllowed("exec", "run"))
+		assert.Equal(t, "ci-runner", config.Credentials[1].CommonName)
+	}
+}
+
+func TestServer_StartWithConfig(t *testing.T) {
+	config, err := NewConfigFromURL("test/start.yaml")
+	if !assert.Nil(t, err) {
+		return
+	}
+	server, err := NewWithConfig(config)
+	if !assert.Nil(t, err) {
+		return
+	}
+	go server.Start()
+	time.Sleep(500 * time.Millisecond)
+
+	var useCases = []struct {
+		description  string
+		token        string
+		expectStatus int
+		expectError  string
+	}{
+		{description: "authentication required", expectStatus: http.StatusUnauthorized, expectError: "authentication required"},
+		{description: "workflow actions allowed", token: "runner-token", expectStatus: http.StatusOK},
+		{description: "workflow action not allowed", token: "workflow-token", expectStatus: http.StatusOK, expectError: "workflow is not allowed to run nop:nop"},
+	}
+	for _, useCase := range useCases {
+		body, _ := json.Marshal(&Request{ServiceRequest: &workflow.RunRequest{URL: "test/nested.csv", Tasks: "*"}})
+		httpRequest, err := http.NewRequest("POST", "http://127.0.0.1:8438/v1/endly/service/workflow/run/", bytes.NewReader(body))
+		assert.Nil(t, err)
+		if useCase.token != "" {
+			httpRequest.Header.Set("Authorization", "Bearer "+useCase.token)
+		}
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		response := &Response{}
+		assert.Nil(t, json.NewDecoder(httpResponse.Body).Decode(response), useCase.description)
+		_ = httpResponse.Body.Close()
+		assert.Equal(t, useCase.expectStatus, httpResponse.StatusCode, useCase.description)
+		if useCase.expectError == "" {
+			assert.Equal(t, "", response.Error, useCase.description)
+			continue
+		}
+		assert.True(t, strings.Contains(response.Error, useCase.expectError), useCase.description+": "+response.Error)
+	}
+}
+
+//newTestCertificate creates ECDSA certificate signed by parent, self signed CA if parent is nil
+func newTestCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	DER, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.Nil(t, err)
+	certificate, err := x509.ParseCertificate(DER)
+	assert.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	return certificate, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: DER}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestServer_Auth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "endly-server")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	CA, CAKey, CAPEM, _ := newTestCertificate(t, "endly-ca", nil, nil)
+	_, _, serverPEM, serverKeyPEM := newTestCertificate(t, "127.0.0.1", CA, CAKey)
+	_, _, clientPEM, clientKeyPEM := newTestCertificate(t, "ci-runner", CA, CAKey)
+	for name, content := range map[string][]byte{"ca.pem": CAPEM, "server.pem": serverPEM, "server-key.pem": serverKeyPEM} {
+		assert.Nil(t, ioutil.WriteFile(path.Join(dir, name), content, 0600))
+	}
+
+	server, err := NewWithConfig(&Config{
+		Port: "8435",
+		TLS: &TLSConfig{
+			CertFile:     path.Join(dir, "server.pem"),
+			KeyFile:      path.Join(dir, "server-key.pem"),
+			ClientCAFile: path.Join(dir, "ca.pem"),
+		},
+		Credentials: []*Credential{
+			{Name: "dev", Token: "dev-token", Allow: []string{"nop:*"}},
+			{Name: "ci", CommonName: "ci-runner", Allow: []string{"*"}},
+		},
+	})
+	if !assert.Nil(t, err) {
+		return
+	}
+	go server.Start()
+	time.Sleep(500 * time.Millisecond)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(CA)
+	clientCertificate, err := tls.X509KeyPair(clientPEM, clientKeyPEM)
+	assert.Nil(t, err)
+
+	var useCases = []struct {
+		description  string
+		URI          string
+		token        string
+		certificates []tls.Certificate
+		expectStatus int
+		expectError  string
+	}{
+		{description: "no credentials", URI: "service/nop/nop/", expectStatus: http.StatusUnauthorized, expectError: "authentication required"},
+		{description: "invalid token", URI: "service/nop/nop/", token: "abc", expectStatus: http.StatusUnauthorized, expectError: "invalid bearer token"},
+		{description: "token allowed", URI: "service/nop/nop/", token: "dev-token", expectStatus: http.StatusOK},
+		{description: "token not allowed", URI: "service/workflow/print/", token: "dev-token", expectStatus: http.StatusForbidden, expectError: "dev is not allowed to run workflow:print"},
+		{description: "job not allowed", URI: "job/workflow/print/", token: "dev-token", expectStatus: http.StatusForbidden, expectError: "dev is not allowed to run workflow:print"},
+		{description: "client certificate", URI: "service/nop/nop/", certificates: []tls.Certificate{clientCertificate}, expectStatus: http.StatusOK},
+	}
+
+	for _, useCase := range useCases {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: useCase.certificates}}}
+		body, _ := json.Marshal(&Request{ServiceRequest: &endly.NopRequest{In: "abc"}})
+		httpRequest, err := http.NewRequest("POST", "https://127.0.0.1:8435/v1/endly/"+useCase.URI, bytes.NewReader(body))
+		assert.Nil(t, err)
+		if useCase.token != "" {
+			httpRequest.Header.Set("Authorization", "Bearer "+useCase.token)
+		}
+		httpResponse, err := client.Do(httpRequest)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		response := &Response{}
+		assert.Nil(t, json.NewDecoder(httpResponse.Body).Decode(response), useCase.description)
+		_ = httpResponse.Body.Close()
+		assert.Equal(t, useCase.expectStatus, httpResponse.StatusCode, useCase.description)
+		assert.Equal(t, useCase.expectError, response.Error, useCase.description)
+	}
+
+	{ //catalog lists actions allowed for credential
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+		httpRequest, _ := http.NewRequest("GET", "https://127.0.0.1:8435/v1/endly/services", nil)
+		httpRequest.Header.Set("Authorization", "Bearer dev-token")
+		httpResponse, err := client.Do(httpRequest)
+		if !assert.Nil(t, err) {
+			return
+		}
+		var services = make([]*meta.ServiceEntry, 0)
+		assert.Nil(t, json.NewDecoder(httpResponse.Body).Decode(&services))
+		_ = httpResponse.Body.Close()
+		if assert.Equal(t, 1, len(services)) {
+			assert.Equal(t, "nop", services[0].ID)
+		}
+	}
+
+	{ //job submitted with token can not be accessed by other credential
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{clientCertificate}}}}
+		body, _ := json.Marshal(&Request{ServiceRequest: &endly.NopRequest{In: "abc"}})
+		httpRequest, _ := http.NewRequest("POST", "https://127.0.0.1:8435/v1/endly/job/nop/nop/", bytes.NewReader(body))
+		httpRequest.Header.Set("Authorization", "Bearer dev-token")
+		httpResponse, err := client.Do(httpRequest)
+		if !assert.Nil(t, err) {
+			return
+		}
+		info := &JobInfo{}
+		assert.Nil(t, json.NewDecoder(httpResponse.Body).Decode(info))
+		_ = httpResponse.Body.Close()
+
+		httpResponse, err = client.Get("https://127.0.0.1:8435/v1/endly/job/" + info.ID + "/")
+		if !assert.Nil(t, err) {
+			return
+		}
+		_ = httpResponse.Body.Close()
+		assert.Equal(t, http.StatusForbidden, httpResponse.StatusCode)
+
+		httpRequest, _ = http.NewRequest("GET", "https://127.0.0.1:8435/v1/endly/job/"+info.ID+"/", nil)
+		httpRequest.Header.Set("Authorization", "Bearer dev-token")
+		httpResponse, err = client.Do(httpRequest)
+		if !assert.Nil(t, err) {
+			return
+		}
+		_ = httpResponse.Body.Close()
+		assert.Equal(t, http.StatusOK, httpResponse.StatusCode)
+	}
+}
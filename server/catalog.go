@@ -0,0 +1,91 @@
+package server
+
+import (
+	"github.com/viant/endly/meta"
+	"github.com/viant/toolbox"
+	"net/http"
+	"sync"
+)
+
+//catalog represents cached service catalog and OpenAPI documents, keyed by credential name
+type catalog struct {
+	*meta.Service
+	services map[string][]*meta.ServiceEntry
+	openAPI  map[string]*meta.OpenAPI
+	mux      sync.Mutex
+}
+
+func (c *catalog) serviceEntries(credential *Credential) []*meta.ServiceEntry {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	key := credentialName(credential)
+	if _, ok := c.services[key]; !ok {
+		c.services[key] = c.Catalog(actionFilter(credential))
+	}
+	return c.services[key]
+}
+
+func (c *catalog) openAPIDocument(credential *Credential) *meta.OpenAPI {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	key := credentialName(credential)
+	if _, ok := c.openAPI[key]; !ok {
+		c.openAPI[key] = c.OpenAPI(actionFilter(credential))
+	}
+	return c.openAPI[key]
+}
+
+func credentialName(credential *Credential) string {
+	if credential == nil {
+		return ""
+	}
+	return credential.Name
+}
+
+//actionFilter returns filter matching actions allowed by supplied credential, nil credential allows all actions
+func actionFilter(credential *Credential) meta.ActionFilter {
+	if credential == nil {
+		return nil
+	}
+	return credential.IsAllowed
+}
+
+func newCatalog(service *meta.Service) *catalog {
+	return &catalog{
+		Service:  service,
+		services: make(map[string][]*meta.ServiceEntry),
+		openAPI:  make(map[string]*meta.OpenAPI),
+	}
+}
+
+//listServices returns registered services with actions allowed for authenticated client
+func (s *Server) listServices(httpRequest *http.Request) ([]*meta.ServiceEntry, error) {
+	credential, err := s.authenticate(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	return s.catalog.serviceEntries(credential), nil
+}
+
+//openAPIDocument returns OpenAPI document of service actions allowed for authenticated client
+func (s *Server) openAPIDocument(httpRequest *http.Request) (*meta.OpenAPI, error) {
+	credential, err := s.authenticate(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	return s.catalog.openAPIDocument(credential), nil
+}
+
+func (s *Server) catalogHandler(serviceRouting *toolbox.ServiceRouting, httpRequest *http.Request, httpResponse http.ResponseWriter, uriParameters map[string]interface{}) error {
+	var response interface{}
+	var err error
+	if serviceRouting.URI == openAPIURI {
+		response, err = s.openAPIDocument(httpRequest)
+	} else {
+		response, err = s.listServices(httpRequest)
+	}
+	if err != nil {
+		return writeErrorResponse(serviceRouting, httpRequest, httpResponse, err)
+	}
+	return toolbox.WriteServiceRoutingResponse(httpResponse, httpRequest, serviceRouting, response)
+}
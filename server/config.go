@@ -0,0 +1,113 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/viant/toolbox/url"
+	"io/ioutil"
+	"strings"
+)
+
+//Config represents server config
+type Config struct {
+	Port        string
+	TLS         *TLSConfig    `description:"optional TLS config, if empty server listens in plain HTTP"`
+	Credentials []*Credential `description:"client credentials, if empty server does not require authentication"`
+}
+
+//Validate checks if config is valid
+func (c *Config) Validate() error {
+	if c.Port == "" {
+		return errors.New("port was empty")
+	}
+	if c.TLS != nil {
+		if err := c.TLS.Validate(); err != nil {
+			return err
+		}
+	}
+	for i, credential := range c.Credentials {
+		if credential.Name == "" {
+			return fmt.Errorf("credentials[%d].name was empty", i)
+		}
+		if credential.Token == "" && credential.CommonName == "" {
+			return fmt.Errorf("%v: token and commonName were empty", credential.Name)
+		}
+		if credential.CommonName != "" && (c.TLS == nil || c.TLS.ClientCAFile == "") {
+			return fmt.Errorf("%v: tls.clientCAFile was empty, it is required to verify client certificate", credential.Name)
+		}
+	}
+	return nil
+}
+
+//TLSConfig represents server TLS config
+type TLSConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string `description:"PEM CA bundle used to verify client certificates, enables mTLS authentication"`
+}
+
+//Validate checks if TLS config is valid
+func (c *TLSConfig) Validate() error {
+	if c.CertFile == "" {
+		return errors.New("tls.certFile was empty")
+	}
+	if c.KeyFile == "" {
+		return errors.New("tls.keyFile was empty")
+	}
+	return nil
+}
+
+//tlsConfig returns TLS config, with client CA client certificate is verified if given, so that bearer token clients can still connect
+func (c *TLSConfig) tlsConfig() (*tls.Config, error) {
+	var result = &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.ClientCAFile == "" {
+		return result, nil
+	}
+	PEM, err := ioutil.ReadFile(c.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %v, %v", c.ClientCAFile, err)
+	}
+	result.ClientCAs = x509.NewCertPool()
+	if !result.ClientCAs.AppendCertsFromPEM(PEM) {
+		return nil, fmt.Errorf("failed to parse client CA: %v", c.ClientCAFile)
+	}
+	result.ClientAuth = tls.VerifyClientCertIfGiven
+	return result, nil
+}
+
+//Credential represents server client credential with allowed service actions
+type Credential struct {
+	Name       string
+	Token      string   `description:"bearer token"`
+	CommonName string   `description:"client certificate subject common name, used with mTLS"`
+	Allow      []string `description:"allowed service:action list, * matches any service or action, i.e. workflow:run, http/runner:*"`
+}
+
+//IsAllowed returns true if credential allows supplied service action
+func (c *Credential) IsAllowed(service, action string) bool {
+	for _, candidate := range c.Allow {
+		if candidate == "*" {
+			return true
+		}
+		allowedService, allowedAction := candidate, "*"
+		if index := strings.Index(candidate, ":"); index != -1 {
+			allowedService, allowedAction = string(candidate[:index]), string(candidate[index+1:])
+		}
+		if (allowedService == "*" || allowedService == service) && (allowedAction == "*" || allowedAction == action) {
+			return true
+		}
+	}
+	return false
+}
+
+//NewConfigFromURL creates a new server config from supplied URL
+func NewConfigFromURL(URL string) (*Config, error) {
+	var result = &Config{}
+	var resource = url.NewResource(URL)
+	if err := resource.Decode(result); err != nil {
+		return nil, fmt.Errorf("failed to load server config: %v, %v", URL, err)
+	}
+	return result, result.Validate()
+}
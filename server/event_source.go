@@ -0,0 +1,153 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/viant/endly/cli"
+	"github.com/viant/toolbox"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	//ResponseEvent represents server-sent event name of the final service response
+	ResponseEvent = "response"
+)
+
+//eventSource represents server-sent events writer, each written event stream JSON line is sent as a message event
+type eventSource struct {
+	writer   http.ResponseWriter
+	flusher  http.Flusher
+	sequence int
+	mux      sync.Mutex
+}
+
+//Write sends supplied event stream JSON line as a message event
+func (s *eventSource) Write(line []byte) (int, error) {
+	s.mux.Lock()
+	s.sequence++
+	id := s.sequence
+	s.mux.Unlock()
+	if err := s.Send(toolbox.AsString(id), "", bytes.TrimSpace(line)); err != nil {
+		return 0, err
+	}
+	return len(line), nil
+}
+
+//Send sends server-sent event, empty event name is received as message event
+func (s *eventSource) Send(id, event string, data []byte) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	var buffer = new(bytes.Buffer)
+	if id != "" {
+		buffer.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		buffer.WriteString("event: " + event + "\n")
+	}
+	buffer.WriteString("data: ")
+	buffer.Write(data)
+	buffer.WriteString("\n\n")
+	if _, err := s.writer.Write(buffer.Bytes()); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+//SendResponse sends final service response event
+func (s *eventSource) SendResponse(response interface{}) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		data, _ = json.Marshal(&Response{Error: fmt.Sprintf("failed to encode response: %v", err)})
+	}
+	return s.Send("", ResponseEvent, data)
+}
+
+func newEventSource(httpResponse http.ResponseWriter) (*eventSource, error) {
+	flusher, ok := httpResponse.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming was not supported by response writer")
+	}
+	httpResponse.Header().Set("Content-Type", eventStreamContentType)
+	httpResponse.Header().Set("Cache-Control", "no-cache")
+	httpResponse.Header().Set("Connection", "keep-alive")
+	httpResponse.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &eventSource{writer: httpResponse, flusher: flusher}, nil
+}
+
+//acceptsEventStream returns true if client accepts server-sent events
+func acceptsEventStream(httpRequest *http.Request) bool {
+	return strings.Contains(httpRequest.Header.Get("Accept"), eventStreamContentType)
+}
+
+//streamService runs service request sending its events as server-sent events followed by response event, run is canceled when client disconnects
+func (s *Server) streamService(serviceName, action string, httpRequest *http.Request, httpResponse http.ResponseWriter) error {
+	credential, err := s.authorize(httpRequest, serviceName, action)
+	if err != nil {
+		return err
+	}
+	service, context, serviceRequest, err := s.newServiceRequest(credential, serviceName, action, httpRequest)
+	if err != nil {
+		return err
+	}
+	defer context.Close()
+	source, err := newEventSource(httpResponse)
+	if err != nil {
+		return err
+	}
+	context.WithBackground(httpRequest.Context())
+	context.SetListener(cli.NewEventStream(source).OnEvent)
+	serviceResponse := service.Run(context, serviceRequest)
+	return source.SendResponse(newResponse(context, serviceResponse))
+}
+
+//streamJob sends job events as server-sent events starting from Last-Event-ID header or offset parameter, once job completes response event is sent
+func (s *Server) streamJob(job *Job, httpRequest *http.Request, httpResponse http.ResponseWriter) error {
+	offset, err := eventOffset(httpRequest)
+	if err != nil {
+		return err
+	}
+	source, err := newEventSource(httpResponse)
+	if err != nil {
+		return err
+	}
+	for {
+		events, changed := job.Next(offset)
+		for i, event := range events.Events {
+			if err = source.Send(toolbox.AsString(offset+i+1), "", event); err != nil {
+				return err
+			}
+		}
+		offset = events.Offset
+		if events.Status != JobStatusRunning {
+			return source.SendResponse(job.Response())
+		}
+		select {
+		case <-changed:
+		case <-httpRequest.Context().Done():
+			return nil
+		}
+	}
+}
+
+//eventOffset returns event offset from Last-Event-ID header or offset parameter
+func eventOffset(httpRequest *http.Request) (int, error) {
+	value := httpRequest.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = httpRequest.Form.Get("offset")
+	}
+	if value == "" {
+		return 0, nil
+	}
+	offset, err := toolbox.ToInt(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset: %v, %v", value, err)
+	}
+	return offset, nil
+}
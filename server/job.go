@@ -0,0 +1,220 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/satori/go.uuid"
+	"github.com/viant/endly"
+	"github.com/viant/endly/cli"
+	"sync"
+	"time"
+)
+
+const (
+	//JobStatusRunning represents running job status
+	JobStatusRunning = "running"
+	//JobStatusDone represents successfully completed job status
+	JobStatusDone = "done"
+	//JobStatusError represents job completed with an error status
+	JobStatusError = "error"
+	//JobStatusCanceled represents canceled job status
+	JobStatusCanceled = "canceled"
+)
+
+//jobRetention represents duration a completed job is kept for status and response retrieval
+const jobRetention = time.Hour
+
+//JobInfo represents job status
+type JobInfo struct {
+	ID         string
+	Service    string
+	Action     string
+	Status     string
+	Error      string     `json:",omitempty"`
+	StartTime  time.Time  `json:",omitempty"`
+	EndTime    *time.Time `json:",omitempty"`
+	EventCount int
+}
+
+//JobEventsResponse represents job events since requested offset
+type JobEventsResponse struct {
+	Status string
+	Offset int               `description:"offset to be used to fetch subsequent events"`
+	Events []json.RawMessage `description:"events encoded as CLI JSON lines event stream records"`
+}
+
+//Job represents asynchronous service request run
+type Job struct {
+	JobInfo
+	owner    string
+	context  *endly.Context
+	cancel   func()
+	canceled bool
+	events   []json.RawMessage
+	changed  chan struct{}
+	response *Response
+	mux      sync.RWMutex
+}
+
+//Info returns job status snapshot
+func (j *Job) Info() *JobInfo {
+	j.mux.RLock()
+	defer j.mux.RUnlock()
+	var result = j.JobInfo
+	result.EventCount = len(j.events)
+	return &result
+}
+
+//Events returns job events starting from supplied offset
+func (j *Job) Events(offset int) *JobEventsResponse {
+	j.mux.RLock()
+	defer j.mux.RUnlock()
+	return j.eventsSince(offset)
+}
+
+//Next returns job events starting from supplied offset and a channel closed once job gets a new event or completes
+func (j *Job) Next(offset int) (*JobEventsResponse, <-chan struct{}) {
+	j.mux.RLock()
+	defer j.mux.RUnlock()
+	return j.eventsSince(offset), j.changed
+}
+
+func (j *Job) eventsSince(offset int) *JobEventsResponse {
+	if offset < 0 || offset > len(j.events) {
+		offset = len(j.events)
+	}
+	var events = make([]json.RawMessage, len(j.events)-offset)
+	copy(events, j.events[offset:])
+	return &JobEventsResponse{
+		Status: j.Status,
+		Offset: len(j.events),
+		Events: events,
+	}
+}
+
+//notify wakes up job event waiters, caller has to hold the lock
+func (j *Job) notify() {
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+//Response returns job service response, response is empty until job completes
+func (j *Job) Response() *Response {
+	j.mux.RLock()
+	defer j.mux.RUnlock()
+	if j.response == nil {
+		return &Response{Status: j.Status}
+	}
+	return j.response
+}
+
+//Cancel cancels running job context
+func (j *Job) Cancel() {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if j.Status != JobStatusRunning {
+		return
+	}
+	j.canceled = true
+	j.cancel()
+}
+
+func (j *Job) isExpired(now time.Time) bool {
+	j.mux.RLock()
+	defer j.mux.RUnlock()
+	return j.EndTime != nil && now.Sub(*j.EndTime) > jobRetention
+}
+
+//Write records event stream JSON line
+func (j *Job) Write(line []byte) (int, error) {
+	var event = make(json.RawMessage, len(line))
+	copy(event, line)
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	j.events = append(j.events, bytes.TrimSpace(event))
+	j.notify()
+	return len(line), nil
+}
+
+func (j *Job) run(service endly.Service, request interface{}) {
+	defer j.context.Close()
+	serviceResponse := service.Run(j.context, request)
+	response := newResponse(j.context, serviceResponse)
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	j.cancel()
+	endTime := time.Now()
+	j.EndTime = &endTime
+	j.Error = response.Error
+	j.response = response
+	switch {
+	case j.canceled:
+		j.Status = JobStatusCanceled
+	case serviceResponse.Error != "":
+		j.Status = JobStatusError
+	default:
+		j.Status = JobStatusDone
+	}
+	response.Status = j.Status
+	j.notify()
+}
+
+//newJob creates a job, job context background is cancelable and its events are recorded with CLI event stream encoding
+func newJob(serviceName, action, owner string, ctx *endly.Context) (*Job, error) {
+	UUID, err := uuid.NewV1()
+	if err != nil {
+		return nil, err
+	}
+	background, cancel := context.WithCancel(ctx.Background())
+	ctx.WithBackground(background)
+	var result = &Job{
+		JobInfo: JobInfo{
+			ID:        UUID.String(),
+			Service:   serviceName,
+			Action:    action,
+			Status:    JobStatusRunning,
+			StartTime: time.Now(),
+		},
+		owner:   owner,
+		context: ctx,
+		cancel:  cancel,
+		events:  make([]json.RawMessage, 0),
+		changed: make(chan struct{}),
+	}
+	ctx.SetListener(cli.NewEventStream(result).OnEvent)
+	return result, nil
+}
+
+//jobs represents job registry
+type jobs struct {
+	registry map[string]*Job
+	mux      sync.RWMutex
+}
+
+func (j *jobs) put(job *Job) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	now := time.Now()
+	for id, candidate := range j.registry {
+		if candidate.isExpired(now) {
+			delete(j.registry, id)
+		}
+	}
+	j.registry[job.ID] = job
+}
+
+func (j *jobs) get(id string) (*Job, error) {
+	j.mux.RLock()
+	defer j.mux.RUnlock()
+	job, ok := j.registry[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown job: %v", id)
+	}
+	return job, nil
+}
+
+func newJobs() *jobs {
+	return &jobs{registry: make(map[string]*Job)}
+}
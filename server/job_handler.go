@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"net/http"
+)
+
+//jobHandlerFunc represents job route handler
+type jobHandlerFunc func(job *Job, httpRequest *http.Request) (interface{}, error)
+
+//submitJob starts service request in the background, returned job ID can be used to poll its status, events and response or to cancel it
+func (s *Server) submitJob(serviceName, action string, httpRequest *http.Request) (*JobInfo, error) {
+	credential, err := s.authorize(httpRequest, serviceName, action)
+	if err != nil {
+		return nil, err
+	}
+	var owner string
+	if credential != nil {
+		owner = credential.Name
+	}
+	service, context, serviceRequest, err := s.newServiceRequest(credential, serviceName, action, httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	job, err := newJob(serviceName, action, owner, context)
+	if err != nil {
+		context.Close()
+		return nil, err
+	}
+	s.jobs.put(job)
+	go job.run(service, serviceRequest)
+	return job.Info(), nil
+}
+
+func (s *Server) submitJobHandler(serviceRouting *toolbox.ServiceRouting, httpRequest *http.Request, httpResponse http.ResponseWriter, uriParameters map[string]interface{}) error {
+	serviceName, ok := uriParameters["service"]
+	if !ok {
+		return writeErrorResponse(serviceRouting, httpRequest, httpResponse, fmt.Errorf("service name was missing %v", uriParameters))
+	}
+	action, ok := uriParameters["action"]
+	if !ok {
+		return writeErrorResponse(serviceRouting, httpRequest, httpResponse, fmt.Errorf("action was missing %v", uriParameters))
+	}
+	info, err := s.submitJob(toolbox.AsString(serviceName), toolbox.AsString(action), httpRequest)
+	if err != nil {
+		return writeErrorResponse(serviceRouting, httpRequest, httpResponse, err)
+	}
+	return toolbox.WriteServiceRoutingResponse(httpResponse, httpRequest, serviceRouting, info)
+}
+
+func (s *Server) jobStatus(job *Job, httpRequest *http.Request) (interface{}, error) {
+	return job.Info(), nil
+}
+
+//jobEvents returns job events starting from offset parameter
+func (s *Server) jobEvents(job *Job, httpRequest *http.Request) (interface{}, error) {
+	offset, err := eventOffset(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	return job.Events(offset), nil
+}
+
+func (s *Server) jobResponse(job *Job, httpRequest *http.Request) (interface{}, error) {
+	return job.Response(), nil
+}
+
+func (s *Server) cancelJob(job *Job, httpRequest *http.Request) (interface{}, error) {
+	job.Cancel()
+	return job.Info(), nil
+}
+
+//getJob returns job for id URI parameter if http request is authorized to access it
+func (s *Server) getJob(httpRequest *http.Request, uriParameters map[string]interface{}) (*Job, error) {
+	job, err := s.jobs.get(toolbox.AsString(uriParameters["id"]))
+	if err != nil {
+		return nil, err
+	}
+	return job, s.authorizeJob(httpRequest, job)
+}
+
+//jobHandler returns route handler invoker resolving job from id URI parameter
+func (s *Server) jobHandler(handler jobHandlerFunc) toolbox.HandlerInvoker {
+	return func(serviceRouting *toolbox.ServiceRouting, httpRequest *http.Request, httpResponse http.ResponseWriter, uriParameters map[string]interface{}) error {
+		job, err := s.getJob(httpRequest, uriParameters)
+		if err != nil {
+			return writeErrorResponse(serviceRouting, httpRequest, httpResponse, err)
+		}
+		response, err := handler(job, httpRequest)
+		if err != nil {
+			return writeErrorResponse(serviceRouting, httpRequest, httpResponse, err)
+		}
+		return toolbox.WriteServiceRoutingResponse(httpResponse, httpRequest, serviceRouting, response)
+	}
+}
+
+func (s *Server) jobStreamHandler(serviceRouting *toolbox.ServiceRouting, httpRequest *http.Request, httpResponse http.ResponseWriter, uriParameters map[string]interface{}) error {
+	job, err := s.getJob(httpRequest, uriParameters)
+	if err == nil {
+		err = s.streamJob(job, httpRequest, httpResponse)
+	}
+	if err != nil {
+		return writeErrorResponse(serviceRouting, httpRequest, httpResponse, err)
+	}
+	return nil
+}
+
+//writeErrorResponse writes error response, authentication and authorization errors are written with their HTTP status code
+func writeErrorResponse(serviceRouting *toolbox.ServiceRouting, httpRequest *http.Request, httpResponse http.ResponseWriter, err error) error {
+	if authErr, ok := err.(*authError); ok {
+		httpResponse.Header().Set("Content-Type", "application/json")
+		if authErr.status == http.StatusUnauthorized {
+			httpResponse.Header().Set("WWW-Authenticate", "Bearer")
+		}
+		httpResponse.WriteHeader(authErr.status)
+	}
+	return toolbox.WriteServiceRoutingResponse(httpResponse, httpRequest, serviceRouting, &Response{Error: fmt.Sprintf("%v", err)})
+}
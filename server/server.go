@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/viant/endly"
+	"github.com/viant/endly/meta"
 	"github.com/viant/toolbox"
 	"log"
 	"net/http"
 )
 
+const (
+	servicesURI = "/v1/endly/services"
+	openAPIURI  = "/v1/endly/openapi.json"
+)
+
 //Request represents service request.
 type Request struct {
 	Data           map[string]interface{}
@@ -25,52 +31,71 @@ type Response struct {
 
 //Server represents a server
 type Server struct {
-	port    string
+	config  *Config
 	manager endly.Manager
+	jobs    *jobs
+	catalog *catalog
 }
 
-func (s *Server) requestService(serviceName, action string, httpRequest *http.Request, httpResponse http.ResponseWriter) (*Response, error) {
-	var service endly.Service
-	var serviceRequest interface{}
-	var err error
-	service, err = s.manager.Service(serviceName)
+//newServiceRequest creates a context and decodes service request with its state data from http request body,
+//credential allow-list is checked for every action run with the context, including actions run by workflows
+func (s *Server) newServiceRequest(credential *Credential, serviceName, action string, httpRequest *http.Request) (endly.Service, *endly.Context, interface{}, error) {
+	service, err := s.manager.Service(serviceName)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	context := s.manager.NewContext(toolbox.NewContext())
-	serviceRequest, err = context.NewRequest(serviceName, action, map[string]interface{}{})
+	if credential != nil {
+		context.SetActionAuthorizer(credential.authorizeAction)
+	}
+	serviceRequest, err := context.NewRequest(serviceName, action, map[string]interface{}{})
 	if err != nil {
-		return nil, err
+		context.Close()
+		return nil, nil, nil, err
 	}
-
 	request := &Request{
 		ServiceRequest: serviceRequest,
 	}
 	err = json.NewDecoder(httpRequest.Body).Decode(request)
 	if err != nil {
-		return nil, err
+		context.Close()
+		return nil, nil, nil, err
 	}
+	state := context.State()
+	state.Apply(request.Data)
+	return service, context, request.ServiceRequest, nil
+}
 
+func (s *Server) requestService(serviceName, action string, httpRequest *http.Request, httpResponse http.ResponseWriter) (*Response, error) {
+	credential, err := s.authorize(httpRequest, serviceName, action)
+	if err != nil {
+		return nil, err
+	}
+	service, context, serviceRequest, err := s.newServiceRequest(credential, serviceName, action, httpRequest)
+	if err != nil {
+		return nil, err
+	}
 	defer context.Close()
+	serviceResponse := service.Run(context, serviceRequest)
+	return newResponse(context, serviceResponse), nil
+}
+
+//newResponse creates a response with service response and context state, secrets revealed while running are masked
+func newResponse(context *endly.Context, serviceResponse *endly.ServiceResponse) *Response {
 	state := context.State()
-	state.Apply(request.Data)
-	serviceResponse := service.Run(context, request.ServiceRequest)
 	var response = &Response{
 		Status:   serviceResponse.Status,
 		Error:    serviceResponse.Error,
 		Response: serviceResponse.Response,
 		Data:     state.AsEncodableMap(),
 	}
-	return response, nil
+	return context.Redactor().RedactValue(response).(*Response)
 }
 
 func (s *Server) routeHandler(serviceRouting *toolbox.ServiceRouting, httpRequest *http.Request, httpResponse http.ResponseWriter, uriParameters map[string]interface{}) (err error) {
 	defer func() {
 		if err != nil {
-			serviceResponse := &Response{
-				Error: fmt.Sprintf("%v", err),
-			}
-			err = toolbox.WriteServiceRoutingResponse(httpResponse, httpRequest, serviceRouting, serviceResponse)
+			err = writeErrorResponse(serviceRouting, httpRequest, httpResponse, err)
 		}
 
 	}()
@@ -84,6 +109,9 @@ func (s *Server) routeHandler(serviceRouting *toolbox.ServiceRouting, httpReques
 		return fmt.Errorf("action was missing %v", uriParameters)
 	}
 
+	if acceptsEventStream(httpRequest) {
+		return s.streamService(toolbox.AsString(serviceName), toolbox.AsString(action), httpRequest, httpResponse)
+	}
 	var response *Response
 	response, err = s.requestService(toolbox.AsString(serviceName), toolbox.AsString(action), httpRequest, httpResponse)
 	if err != nil {
@@ -108,23 +136,112 @@ func (s *Server) Start() error {
 			Handler:        s.requestService,
 			HandlerInvoker: s.routeHandler,
 			Parameters:     []string{"service", "action", "@httpRequest", "@httpResponseWriter"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            servicesURI,
+			Handler:        s.listServices,
+			HandlerInvoker: s.catalogHandler,
+			Parameters:     []string{"@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            servicesURI + "/",
+			Handler:        s.listServices,
+			HandlerInvoker: s.catalogHandler,
+			Parameters:     []string{"@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            openAPIURI,
+			Handler:        s.openAPIDocument,
+			HandlerInvoker: s.catalogHandler,
+			Parameters:     []string{"@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "POST",
+			URI:            "/v1/endly/job/{service}/{action}/",
+			Handler:        s.submitJob,
+			HandlerInvoker: s.submitJobHandler,
+			Parameters:     []string{"service", "action", "@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            "/v1/endly/job/{id}/",
+			Handler:        s.jobStatus,
+			HandlerInvoker: s.jobHandler(s.jobStatus),
+			Parameters:     []string{"id", "@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            "/v1/endly/job/{id}/events/",
+			Handler:        s.jobEvents,
+			HandlerInvoker: s.jobHandler(s.jobEvents),
+			Parameters:     []string{"id", "@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            "/v1/endly/job/{id}/response/",
+			Handler:        s.jobResponse,
+			HandlerInvoker: s.jobHandler(s.jobResponse),
+			Parameters:     []string{"id", "@httpRequest"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "GET",
+			URI:            "/v1/endly/job/{id}/stream/",
+			Handler:        s.streamJob,
+			HandlerInvoker: s.jobStreamHandler,
+			Parameters:     []string{"id", "@httpRequest", "@httpResponseWriter"},
+		},
+		toolbox.ServiceRouting{
+			HTTPMethod:     "DELETE",
+			URI:            "/v1/endly/job/{id}/",
+			Handler:        s.cancelJob,
+			HandlerInvoker: s.jobHandler(s.cancelJob),
+			Parameters:     []string{"id", "@httpRequest"},
 		})
 
-	http.HandleFunc("/v1/", func(response http.ResponseWriter, reader *http.Request) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/", func(response http.ResponseWriter, reader *http.Request) {
 		err := router.Route(response, reader)
 		if err != nil {
 			response.WriteHeader(http.StatusInternalServerError)
 		}
 	})
-	fmt.Printf("Started test server on port %v\n", s.port)
-	log.Fatal(http.ListenAndServe(":"+s.port, nil))
+	server := &http.Server{Addr: ":" + s.config.Port, Handler: mux}
+	if s.config.TLS != nil {
+		var err error
+		if server.TLSConfig, err = s.config.TLS.tlsConfig(); err != nil {
+			return err
+		}
+		fmt.Printf("Started test server on port %v (TLS)\n", s.config.Port)
+		log.Fatal(server.ListenAndServeTLS(s.config.TLS.CertFile, s.config.TLS.KeyFile))
+		return nil
+	}
+	fmt.Printf("Started test server on port %v\n", s.config.Port)
+	log.Fatal(server.ListenAndServe())
 	return nil
 }
 
 //New createss a new server for provided port.
 func New(port string) *Server {
+	return newServer(&Config{Port: port})
+}
+
+//NewWithConfig creates a new server for provided config
+func NewWithConfig(config *Config) (*Server, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return newServer(config), nil
+}
+
+func newServer(config *Config) *Server {
+	manager := endly.New()
 	return &Server{
-		port:    port,
-		manager: endly.New(),
+		config:  config,
+		manager: manager,
+		jobs:    newJobs(),
+		catalog: newCatalog(&meta.Service{Manager: manager}),
 	}
 }
@@ -1,9 +1,18 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/endly"
+	"github.com/viant/endly/cli"
+	"github.com/viant/endly/meta"
+	"github.com/viant/endly/workflow"
 	"github.com/viant/toolbox"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 )
@@ -30,3 +39,214 @@ func TestServer_Start(t *testing.T) {
 	assert.True(t, ok)
 
 }
+
+func TestServer_Job(t *testing.T) {
+	server := New("8433")
+	go server.Start()
+	time.Sleep(500 * time.Millisecond)
+	baseURL := "http://127.0.0.1:8433/v1/endly/job/"
+
+	waitForJob := func(ID string) *JobInfo {
+		info := &JobInfo{}
+		for i := 0; i < 100; i++ {
+			info = &JobInfo{}
+			if err := toolbox.RouteToService("get", baseURL+ID+"/", nil, info); err != nil || info.Status != JobStatusRunning {
+				break
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return info
+	}
+
+	{ //completed job
+		submitted := &JobInfo{}
+		err := toolbox.RouteToService("post", baseURL+"nop/nop/", &Request{
+			ServiceRequest: &endly.NopRequest{In: map[string]interface{}{"k1": 1}},
+			Data:           map[string]interface{}{"env": "qa"},
+		}, submitted)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.True(t, submitted.ID != "")
+		info := waitForJob(submitted.ID)
+		assert.Equal(t, JobStatusDone, info.Status)
+		assert.NotNil(t, info.EndTime)
+
+		response := &Response{}
+		err = toolbox.RouteToService("get", baseURL+submitted.ID+"/response/", nil, response)
+		assert.Nil(t, err)
+		assert.Equal(t, JobStatusDone, response.Status)
+		assert.EqualValues(t, map[string]interface{}{"k1": 1.0}, response.Response)
+		assert.EqualValues(t, "qa", response.Data["env"])
+
+		events := &JobEventsResponse{}
+		err = toolbox.RouteToService("get", baseURL+submitted.ID+"/events/?offset=1000", nil, events)
+		assert.Nil(t, err)
+		assert.Equal(t, info.EventCount, events.Offset)
+		assert.Equal(t, 0, len(events.Events))
+	}
+
+	{ //canceled job
+		submitted := &JobInfo{}
+		err := toolbox.RouteToService("post", baseURL+"workflow/run/", &Request{
+			ServiceRequest: &workflow.RunRequest{
+				URL: "../workflow/test/sleep.csv",
+			},
+		}, submitted)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, "", submitted.Error)
+		time.Sleep(1500 * time.Millisecond)
+		canceled := &JobInfo{}
+		err = toolbox.RouteToService("delete", baseURL+submitted.ID+"/", nil, canceled)
+		assert.Nil(t, err)
+		info := waitForJob(submitted.ID)
+		assert.Equal(t, JobStatusCanceled, info.Status)
+		assert.True(t, info.EndTime.Sub(info.StartTime) < 4*time.Second)
+
+		events := &JobEventsResponse{}
+		err = toolbox.RouteToService("get", baseURL+submitted.ID+"/events/", nil, events)
+		assert.Nil(t, err)
+		assert.True(t, len(events.Events) > 0)
+		assert.Equal(t, JobStatusCanceled, events.Status)
+	}
+
+	{ //unknown job
+		response := &Response{}
+		err := toolbox.RouteToService("get", baseURL+"abc/", nil, response)
+		assert.Nil(t, err)
+		assert.Equal(t, "unknown job: abc", response.Error)
+	}
+}
+
+type serverSentEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+func readServerSentEvents(reader io.Reader) []*serverSentEvent {
+	var result = make([]*serverSentEvent, 0)
+	var event = &serverSentEvent{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			result = append(result, event)
+			event = &serverSentEvent{}
+		case strings.HasPrefix(line, "id: "):
+			event.ID = line[4:]
+		case strings.HasPrefix(line, "event: "):
+			event.Event = line[7:]
+		case strings.HasPrefix(line, "data: "):
+			event.Data = line[6:]
+		}
+	}
+	return result
+}
+
+func TestServer_Stream(t *testing.T) {
+	server := New("8434")
+	go server.Start()
+	time.Sleep(500 * time.Millisecond)
+	body, err := json.Marshal(&Request{ServiceRequest: &workflow.RunRequest{URL: "../workflow/test/nop/workflow.csv", Name: "nop"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	{ //service request stream
+		httpRequest, err := http.NewRequest("POST", "http://127.0.0.1:8434/v1/endly/service/workflow/run/", bytes.NewReader(body))
+		if !assert.Nil(t, err) {
+			return
+		}
+		httpRequest.Header.Set("Accept", "text/event-stream")
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer httpResponse.Body.Close()
+		assert.Equal(t, "text/event-stream", httpResponse.Header.Get("Content-Type"))
+		events := readServerSentEvents(httpResponse.Body)
+		if !assert.True(t, len(events) > 1) {
+			return
+		}
+		var actions = make([]string, 0)
+		for _, event := range events[:len(events)-1] {
+			assert.Equal(t, "", event.Event)
+			record := &cli.EventRecord{}
+			assert.Nil(t, json.Unmarshal([]byte(event.Data), record))
+			if record.Type == "model_Activity" {
+				actions = append(actions, record.Service+":"+record.Action)
+			}
+		}
+		assert.Equal(t, []string{"nop:nop", "nop:nop", "nop:nop"}, actions)
+		last := events[len(events)-1]
+		assert.Equal(t, ResponseEvent, last.Event)
+		response := &Response{}
+		assert.Nil(t, json.Unmarshal([]byte(last.Data), response))
+		assert.Equal(t, "ok", response.Status, response.Error)
+	}
+
+	{ //job stream
+		submitted := &JobInfo{}
+		err := toolbox.RouteToService("post", "http://127.0.0.1:8434/v1/endly/job/workflow/run/", &Request{ServiceRequest: &workflow.RunRequest{URL: "../workflow/test/nop/workflow.csv", Name: "nop"}}, submitted)
+		if !assert.Nil(t, err) {
+			return
+		}
+		httpResponse, err := http.Get("http://127.0.0.1:8434/v1/endly/job/" + submitted.ID + "/stream/?offset=2")
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer httpResponse.Body.Close()
+		events := readServerSentEvents(httpResponse.Body)
+		if !assert.True(t, len(events) > 1) {
+			return
+		}
+		assert.Equal(t, "3", events[0].ID)
+		last := events[len(events)-1]
+		assert.Equal(t, ResponseEvent, last.Event)
+		response := &Response{}
+		assert.Nil(t, json.Unmarshal([]byte(last.Data), response))
+		assert.Equal(t, JobStatusDone, response.Status)
+
+		info := &JobInfo{}
+		assert.Nil(t, toolbox.RouteToService("get", "http://127.0.0.1:8434/v1/endly/job/"+submitted.ID+"/", nil, info))
+		assert.Equal(t, info.EventCount, len(events)-1+2)
+	}
+}
+
+func TestServer_Catalog(t *testing.T) {
+	server := New("8436")
+	go server.Start()
+	time.Sleep(500 * time.Millisecond)
+
+	var services = make([]*meta.ServiceEntry, 0)
+	err := toolbox.RouteToService("get", "http://127.0.0.1:8436/v1/endly/services", nil, &services)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var actions = make(map[string]bool)
+	for _, service := range services {
+		for _, action := range service.Actions {
+			actions[service.ID+":"+action.Action] = true
+		}
+	}
+	assert.True(t, actions["nop:nop"])
+	assert.True(t, actions["workflow:run"])
+
+	var document = &meta.OpenAPI{}
+	err = toolbox.RouteToService("get", "http://127.0.0.1:8436/v1/endly/openapi.json", nil, document)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, meta.OpenAPIVersion, document.OpenAPI)
+	path, ok := document.Paths["/v1/endly/service/workflow/run/"]
+	if assert.True(t, ok) {
+		assert.Equal(t, "workflow_run", path.Post.OperationID)
+	}
+	_, ok = document.Components.Schemas["workflow.RunRequest"]
+	assert.True(t, ok)
+}
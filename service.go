@@ -88,6 +88,11 @@ func (s *AbstractService) Run(context *Context, request interface{}) (response *
 		}
 	}
 
+	if err = context.AuthorizeAction(s.ID(), service.Action); err != nil {
+		err = NewError(s.ID(), service.Action, err)
+		return response
+	}
+
 	if initializer, ok := request.(Initializer); ok {
 		if err = initializer.Init(); err != nil {
 			err = NewError(s.ID(), service.Action, fmt.Errorf("init %T failed: %v", request, err))
@@ -132,7 +137,10 @@ func (s *AbstractService) Sleep(context *Context, sleepTimeMs int) {
 		if context.IsLoggingEnabled() {
 			context.Publish(msg.NewSleepEvent(sleepTimeMs))
 		}
-		time.Sleep(sleepTime)
+		select {
+		case <-time.After(sleepTime):
+		case <-context.Background().Done():
+		}
 		return
 	}
 
@@ -141,7 +149,7 @@ func (s *AbstractService) Sleep(context *Context, sleepTimeMs int) {
 		if context.IsLoggingEnabled() {
 			context.Publish(msg.NewSleepEvent(1000))
 		}
-		if time.Now().Sub(startTime) >= sleepTime {
+		if time.Now().Sub(startTime) >= sleepTime || context.Background().Err() != nil {
 			break
 		}
 		time.Sleep(time.Second)
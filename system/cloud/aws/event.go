@@ -31,3 +31,7 @@ func NewOutputEvent(message string, tag string, value interface{}) *OutputEvent
 		Value:   value,
 	}
 }
+
+func init() {
+	msg.RegisterType(&OutputEvent{})
+}
@@ -6,9 +6,12 @@ import (
 	"github.com/viant/endly"
 	"github.com/viant/endly/model"
 	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/secret"
 	"github.com/viant/toolbox/ssh"
 	"github.com/viant/toolbox/url"
 	"path"
+	"runtime"
+	"strings"
 )
 
 var sessionsKey = (*model.Sessions)(nil)
@@ -28,6 +31,15 @@ func TerminalSessions(context *endly.Context) model.Sessions {
 	return *result
 }
 
+//trackSecrets registers command secrets credentials with context redactor, so that they are masked in published events
+func trackSecrets(context *endly.Context, secrets secret.Secrets) {
+	for _, credentials := range secrets {
+		if config, err := context.Secrets.GetCredentials(string(credentials)); err == nil {
+			context.Redactor().AddSecretFields(config)
+		}
+	}
+}
+
 //SessionID returns session I
 func SessionID(context *endly.Context, target *url.Resource) string {
 	username := ""
@@ -107,10 +119,24 @@ func NewSSHMultiRecordingContext(manager endly.Manager, sessions map[string]*url
 
 }
 
+//callerDirectory returns directory of the first caller outside of this file, inlined frames are resolved
+func callerDirectory() string {
+	var pcs = make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasSuffix(frame.File, "/exec/helper.go") {
+			return path.Dir(frame.File)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
 //GetReplayService return replay service
 func GetReplayService(basedir string) (ssh.Service, error) {
-	fileName, _, _ := toolbox.DiscoverCaller(3, 10, "helper.go")
-	parent, _ := path.Split(fileName)
+	parent := callerDirectory()
 	replayDirectory := path.Join(parent, basedir)
 	if !toolbox.FileExists(replayDirectory) {
 		return nil, fmt.Errorf("replay directory does not exist: %v", replayDirectory)
@@ -1,8 +1,12 @@
 package exec
 
-import "github.com/viant/endly"
+import (
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
+)
 
 func init() {
+	msg.RegisterType(&StdinEvent{}, &StdoutEvent{})
 	endly.Registry.Register(func() endly.Service {
 		return New()
 	})
@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"context"
 	"fmt"
 	"github.com/viant/endly"
 	"github.com/viant/endly/model"
@@ -15,6 +16,8 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
+	"time"
 )
 
 //ServiceID represent system executor service id
@@ -50,6 +53,7 @@ func (s *execService) openSSHService(context *endly.Context, request *OpenSessio
 	if err != nil {
 		return nil, err
 	}
+	context.Redactor().AddSecretFields(authConfig)
 	hostname, port := s.GetHostAndSSHPort(target)
 	return ssh.NewService(hostname, port, authConfig)
 }
@@ -202,8 +206,52 @@ func (s *execService) changeDirectory(context *endly.Context, session *model.Ses
 	return result, err
 }
 
+//deadlineErr returns context error, or deadline exceeded error once deadline passed before context timer fired
+func deadlineErr(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
 func (s *execService) run(context *endly.Context, session *model.Session, command string, listener ssh.Listener, timeoutMs int, terminators ...string) (stdout string, err error) {
-	if stdout, err = session.Run(command, listener, timeoutMs, terminators...); err == nil {
+	ctx := context.Background()
+	if err = ctx.Err(); err != nil { //workflow node timeout or cancellation
+		return "", err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		remainingMs := int(time.Until(deadline)/time.Millisecond) + 1
+		if timeoutMs <= 0 || remainingMs < timeoutMs {
+			timeoutMs = remainingMs
+		}
+		//closing shell stops running command, session is reconnected by subsequent run
+		var closeOnce sync.Once
+		closeShell := func() {
+			closeOnce.Do(session.MultiCommandSession.Close)
+		}
+		done := make(chan bool)
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				closeShell()
+			case <-done:
+			}
+		}()
+		defer func() {
+			if deadlineErr(ctx) != nil {
+				closeShell()
+			}
+		}()
+	}
+	stdout, err = session.Run(command, listener, timeoutMs, terminators...)
+	if ctxErr := deadlineErr(ctx); ctxErr != nil {
+		return stdout, ctxErr
+	}
+	if err == nil {
 		return stdout, err
 	}
 	if err == ssh.ErrTerminated {
@@ -378,11 +426,12 @@ func (s *execService) executeCommand(context *endly.Context, session *model.Sess
 	if err != nil {
 		return err
 	}
+	trackSecrets(context, request.Secrets)
 
 	var listener ssh.Listener
 
 	//troubleshooting secrets - DO NOT USE unless really needed
-	if os.Getenv("ENDLY_SECRET_REVEAL") == "true" {
+	if os.Getenv(endly.SecretRevealEnvKey) == "true" {
 		securedCommand = insecureCommand
 	}
 	s.Begin(context, NewSdtinEvent(session.ID, securedCommand))
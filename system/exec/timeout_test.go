@@ -0,0 +1,57 @@
+package exec
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/ssh"
+	"testing"
+	"time"
+)
+
+//blockingSession represents shell session running command until timeout or close
+type blockingSession struct {
+	closed    chan bool
+	timeoutMs int
+}
+
+func (s *blockingSession) Run(command string, listener ssh.Listener, timeoutMs int, terminators ...string) (string, error) {
+	s.timeoutMs = timeoutMs
+	select {
+	case <-s.closed:
+		return "", ssh.ErrTerminated
+	case <-time.After(time.Duration(timeoutMs) * time.Millisecond):
+		return "", nil
+	}
+}
+
+func (s *blockingSession) ShellPrompt() string { return "$" }
+
+func (s *blockingSession) System() string { return "linux" }
+
+func (s *blockingSession) Reconnect() error { return nil }
+
+func (s *blockingSession) Close() { close(s.closed) }
+
+func TestExecService_RunWithDeadline(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	defer context.Close()
+	service := New().(*execService)
+	shell := &blockingSession{closed: make(chan bool)}
+	session := &model.Session{MultiCommandSession: shell}
+
+	restore := context.WithTimeout(100 * time.Millisecond)
+	defer restore()
+	startTime := time.Now()
+	_, err := service.run(context, session, "sleep 60", nil, 60000)
+	assert.True(t, endly.IsTimeoutError(err), err)
+	assert.True(t, time.Since(startTime) < 5*time.Second)
+	assert.True(t, shell.timeoutMs <= 101, shell.timeoutMs)
+	select {
+	case <-shell.closed:
+	default:
+		assert.Fail(t, "expected session to be closed once deadline passed")
+	}
+}
@@ -39,3 +39,7 @@ func NewOutputEvent(message string, tag string, value interface{}) *OutputEvent
 		Value:   value,
 	}
 }
+
+func init() {
+	msg.RegisterType(&OutputEvent{})
+}
@@ -46,6 +46,11 @@ func (s *service) reveal(context *endly.Context, request *RevealRequest) (*Revea
 	}
 	response := &RevealResponse{}
 	response.Data = secret.String()
+	if secret.Target == nil {
+		context.Redactor().Add(response.Data)
+	} else {
+		context.Redactor().AddSecretFields(secret.Target)
+	}
 	switch actual := secret.Target.(type) {
 	case *cred.Generic:
 		response.Generic = actual
@@ -78,6 +83,7 @@ func (s *service) signJWT(context *endly.Context, request *SignJWTRequest) (*Sig
 	if err != nil {
 		return nil, err
 	}
+	context.Redactor().Add(token)
 	response := &SignJWTResponse{
 		TokenString: token,
 	}
@@ -85,6 +91,7 @@ func (s *service) signJWT(context *endly.Context, request *SignJWTRequest) (*Sig
 }
 
 func (s *service) verifyJWT(context *endly.Context, request *VerifyJWTRequest) (*VerifyJWTResponse, error) {
+	context.Redactor().Add(request.Token)
 	jwtVerifier := verifier.New(&verifier.Config{RSA: request.PublicKey, CertURL: request.CertURL})
 	if err := jwtVerifier.Init(context.Background()); err != nil {
 		return nil, err
@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"context"
 	"github.com/viant/afs"
 	"github.com/viant/afs/file"
 	"github.com/viant/afs/option"
@@ -40,7 +39,7 @@ func StorageService(ctx *endly.Context, resources ...*url.Resource) (afs.Service
 		if err != nil {
 			return nil, err
 		}
-		if err = fs.Init(context.Background(), resource.URL, options...); err != nil {
+		if err = fs.Init(ctx.Background(), resource.URL, options...); err != nil {
 			return nil, err
 		}
 	}
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"github.com/stretchr/testify/assert"
-	"github.com/viant/afs/option"
 	"github.com/viant/endly"
 	"github.com/viant/toolbox/url"
 	"io/ioutil"
@@ -12,7 +11,7 @@ import (
 func TestService_Upload(t *testing.T) {
 
 	resource := url.NewResource("mem://localhost/data/storage/upload/case004/data.txt")
-	resource.CustomKey = &option.AES256Key{
+	resource.CustomKey = &url.AES256Key{
 		Key: []byte("invalid_key"),
 	}
 
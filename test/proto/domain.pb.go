@@ -1,80 +1,162 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
 // source: domain.proto
 
-/*
-Package gmetric is a generated protocol buffer package.
+package proto
 
-It is generated from these files:
-	domain.proto
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
 
-It has these top-level messages:
-	Message
-*/
-package proto
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
 
-import proto "google.golang.org/protobuf/proto"
-import fmt "fmt"
-import math "math"
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ = proto.Marshal
-var _ = fmt.Errorf
-var _ = math.Inf
+	Id          *int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name        *string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Description *string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the proto package it is being compiled against.
-// A compilation error at this line likely means your copy of the
-// proto package needs to be updated.
-const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_domain_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-// Message a test message
-type Message struct {
-	Id               *int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	Name             *string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	Description      *string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
-	XXX_unrecognized []byte  `json:"-"`
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (m *Message) Reset()                    { *m = Message{} }
-func (m *Message) String() string            { return proto.CompactTextString(m) }
-func (*Message) ProtoMessage()               {}
-func (*Message) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (*Message) ProtoMessage() {}
 
-func (m *Message) GetId() int64 {
-	if m != nil && m.Id != nil {
-		return *m.Id
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
 	}
 	return 0
 }
 
-func (m *Message) GetName() string {
-	if m != nil && m.Name != nil {
-		return *m.Name
+func (x *Message) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (m *Message) GetDescription() string {
-	if m != nil && m.Description != nil {
-		return *m.Description
+func (x *Message) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Message)(nil), "gmetric.Message")
+var File_domain_proto protoreflect.FileDescriptor
+
+var file_domain_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07,
+	0x67, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x22, 0x38, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0a, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x12, 0x0c,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x12, 0x13, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x44, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x2e, 0x76, 0x69, 0x61, 0x6e, 0x74, 0x2e, 0x65,
+	0x6e, 0x64, 0x6c, 0x79, 0x42, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x50, 0x01, 0x5a, 0x27,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x69, 0x61, 0x6e, 0x74,
+	0x2f, 0x65, 0x6e, 0x64, 0x6c, 0x79, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_domain_proto_rawDescOnce sync.Once
+	file_domain_proto_rawDescData = file_domain_proto_rawDesc
+)
+
+func file_domain_proto_rawDescGZIP() []byte {
+	file_domain_proto_rawDescOnce.Do(func() {
+		file_domain_proto_rawDescData = protoimpl.X.CompressGZIP(file_domain_proto_rawDescData)
+	})
+	return file_domain_proto_rawDescData
+}
+
+var file_domain_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_domain_proto_goTypes = []interface{}{
+	(*Message)(nil), // 0: gmetric.Message
+}
+var file_domain_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
 }
 
-func init() { proto.RegisterFile("domain.proto", fileDescriptor0) }
-
-var fileDescriptor0 = []byte{
-	// 128 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xc9, 0xcf, 0x4d,
-	0xcc, 0xcc, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4f, 0xcf, 0x4d, 0x2d, 0x29, 0xca,
-	0x4c, 0x56, 0xb2, 0xe0, 0x62, 0xf7, 0x4d, 0x2d, 0x2e, 0x4e, 0x4c, 0x4f, 0x15, 0xe2, 0xe2, 0x62,
-	0xca, 0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x16, 0xe2, 0xe1, 0x62, 0xc9, 0x4b, 0xcc, 0x4d,
-	0x95, 0x60, 0x52, 0x60, 0xd4, 0xe0, 0x14, 0x12, 0xe6, 0xe2, 0x4e, 0x49, 0x2d, 0x4e, 0x2e, 0xca,
-	0x2c, 0x28, 0xc9, 0xcc, 0xcf, 0x93, 0x60, 0x06, 0x09, 0x3a, 0x49, 0x73, 0xf1, 0x27, 0xe7, 0xe7,
-	0xea, 0x95, 0x65, 0x26, 0xe6, 0x95, 0xe8, 0xa5, 0xe6, 0xa5, 0xe4, 0x54, 0x3a, 0xb1, 0x41, 0xec,
-	0x08, 0x60, 0x04, 0x04, 0x00, 0x00, 0xff, 0xff, 0xb8, 0x9c, 0xc4, 0xe4, 0x6e, 0x00, 0x00, 0x00,
+func init() { file_domain_proto_init() }
+func file_domain_proto_init() {
+	if File_domain_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_domain_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_domain_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_domain_proto_goTypes,
+		DependencyIndexes: file_domain_proto_depIdxs,
+		MessageInfos:      file_domain_proto_msgTypes,
+	}.Build()
+	File_domain_proto = out.File
+	file_domain_proto_rawDesc = nil
+	file_domain_proto_goTypes = nil
+	file_domain_proto_depIdxs = nil
 }
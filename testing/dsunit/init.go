@@ -2,9 +2,11 @@ package dsunit
 
 import (
 	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
 )
 
 func init() {
+	msg.RegisterType(&PopulateDatastoreEvent{}, &RunSQLcriptEvent{})
 	endly.Registry.Register(func() endly.Service {
 		service := New()
 		return service
@@ -0,0 +1,81 @@
+package endpoint
+
+import (
+	"fmt"
+	"github.com/viant/assertly"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/criteria"
+)
+
+//AssertCalls validates received calls with expected calls in received order unless anyOrder is set, count validates number of received calls
+func AssertCalls(context *endly.Context, description string, calls []Call, expect []interface{}, count *int, anyOrder bool) (*assertly.Validation, error) {
+	var validation = &assertly.Validation{Description: description}
+	var actual = make([]interface{}, len(calls))
+	for i, call := range calls {
+		actual[i] = call.AsMap()
+	}
+	if count != nil {
+		if *count == len(actual) {
+			validation.PassedCount++
+		} else {
+			validation.AddFailure(assertly.NewFailure("", "/Count", "call count mismatch", *count, len(actual)))
+		}
+	}
+	var err error
+	if anyOrder {
+		err = assertAnyOrder(context, expect, actual, validation)
+	} else {
+		err = assertInOrder(context, expect, actual, validation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	context.Publish(validation)
+	return validation, nil
+}
+
+//assertInOrder validates expected calls with calls in received order
+func assertInOrder(context *endly.Context, expect, actual []interface{}, validation *assertly.Validation) error {
+	for i, expected := range expect {
+		path := fmt.Sprintf("/[%d]", i)
+		if i >= len(actual) {
+			validation.AddFailure(assertly.NewFailure("", path, "missing call", expected, nil))
+			continue
+		}
+		callValidation, err := criteria.Assert(context, path, expected, actual[i])
+		if err != nil {
+			return err
+		}
+		validation.MergeFrom(callValidation)
+	}
+	return nil
+}
+
+//assertAnyOrder validates each expected call with the first not yet matched call satisfying it
+func assertAnyOrder(context *endly.Context, expect, actual []interface{}, validation *assertly.Validation) error {
+	var matched = make(map[int]bool)
+	for i, expected := range expect {
+		path := fmt.Sprintf("/[%d]", i)
+		var found = false
+		for j := range actual {
+			if matched[j] {
+				continue
+			}
+			callValidation, err := criteria.Assert(context, path, expected, actual[j])
+			if err != nil {
+				return err
+			}
+			if callValidation.HasFailure() {
+				continue
+			}
+			matched[j] = true
+			found = true
+			validation.MergeFrom(callValidation)
+			break
+		}
+		if !found {
+			validation.AddFailure(assertly.NewFailure("", path, "missing call", expected, nil))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package endpoint
+
+import "sync"
+
+//Call represents a call received by an endpoint
+type Call interface {
+	//AsMap returns call as map for assertion
+	AsMap() map[string]interface{}
+}
+
+//Calls represents calls received since listen or reset
+type Calls struct {
+	items []Call
+	mux   sync.Mutex
+}
+
+//Add records received call
+func (c *Calls) Add(call Call) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.items = append(c.items, call)
+}
+
+//List returns received calls
+func (c *Calls) List() []Call {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	var result = make([]Call, len(c.items))
+	copy(result, c.items)
+	return result
+}
+
+//Reset clears received calls
+func (c *Calls) Reset() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.items = nil
+}
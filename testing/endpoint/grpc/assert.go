@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/testing/endpoint"
+)
+
+func (s *service) server(port int) (*Server, error) {
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	server, ok := s.servers[port]
+	if !ok {
+		return nil, fmt.Errorf("endpoint at %v, not found", port)
+	}
+	return server, nil
+}
+
+func (s *service) assert(context *endly.Context, request *AssertRequest) (*AssertResponse, error) {
+	server, err := s.server(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	var response = &AssertResponse{Calls: server.Calls()}
+	description := fmt.Sprintf("grpc/endpoint :%v calls", request.Port)
+	response.Validation, err = endpoint.AssertCalls(context, description, server.calls.List(), request.Expect, request.Count, request.AnyOrder)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *service) reset(context *endly.Context, request *ResetRequest) (*ResetResponse, error) {
+	server, err := s.server(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	response := &ResetResponse{Calls: server.Calls()}
+	server.Reset()
+	return response, nil
+}
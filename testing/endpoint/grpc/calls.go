@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"github.com/viant/toolbox/data"
+	"time"
+)
+
+//Call represents gRPC call received by the endpoint
+type Call struct {
+	Method    string            `description:"method in service/method format"`
+	Metadata  map[string]string `description:"metadata values, multiple values are comma separated"`
+	Message   interface{}       `description:"request message, slice of messages for client streaming method"`
+	Route     string            `json:",omitempty" description:"matched stub route name"`
+	Timestamp time.Time
+}
+
+//AsMap returns call as map for assertion
+func (c *Call) AsMap() map[string]interface{} {
+	var metadata = make(map[string]interface{})
+	for name, value := range c.Metadata {
+		metadata[name] = value
+	}
+	return map[string]interface{}{
+		"Method":   c.Method,
+		"Metadata": metadata,
+		"Message":  c.Message,
+		"Route":    c.Route,
+	}
+}
+
+//asData returns call data used by route predicates and response templates
+func (c *Call) asData() data.Map {
+	var result = data.NewMap()
+	result.Put("method", c.Method)
+	var metadata = data.NewMap()
+	for name, value := range c.Metadata {
+		metadata.Put(name, value)
+	}
+	result.Put("metadata", metadata)
+	result.Put("message", c.Message)
+	return result
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"errors"
+	"github.com/viant/assertly"
+)
+
+//ListenRequest represents gRPC endpoint listen request
+type ListenRequest struct {
+	Port       int
+	ProtoFiles []string `required:"true" description:"proto files with served services"`
+	ImportPath string   `description:"proto import path, the first proto file directory by default"`
+	Routes     Routes   `description:"method stub routes, the first matching route handles a call"`
+}
+
+//Init initialises request
+func (r *ListenRequest) Init() error {
+	return r.Routes.Init()
+}
+
+//Validate checks if request is valid.
+func (r ListenRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	if len(r.ProtoFiles) == 0 {
+		return errors.New("protoFiles were empty")
+	}
+	return nil
+}
+
+//ListenResponse represents gRPC endpoint listen response
+type ListenResponse struct {
+	Services []string       `description:"served service names"`
+	Hits     map[string]int `description:"stub route hit counts keyed by route name"`
+}
+
+//ShutdownRequest represent gRPC endpoint shutdown request
+type ShutdownRequest struct {
+	Port int
+}
+
+//AssertRequest represents gRPC endpoint received calls assert request
+type AssertRequest struct {
+	Port     int
+	Expect   []interface{} `description:"expected calls with Method, Metadata, Message or Route, validated with assertly"`
+	Count    *int          `description:"expected number of calls received since listen or reset"`
+	AnyOrder bool          `description:"flag to match expected calls in any order, received order by default"`
+}
+
+//Validate checks if request is valid.
+func (r AssertRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	return nil
+}
+
+//AssertResponse represents gRPC endpoint assert response with received calls
+type AssertResponse struct {
+	Calls      []*Call
+	Validation *assertly.Validation
+}
+
+//Assertion returns validation slice
+func (r *AssertResponse) Assertion() []*assertly.Validation {
+	if r == nil || r.Validation == nil {
+		return []*assertly.Validation{}
+	}
+	return []*assertly.Validation{r.Validation}
+}
+
+//ResetRequest represents gRPC endpoint received calls and route hit counts reset request
+type ResetRequest struct {
+	Port int
+}
+
+//Validate checks if request is valid.
+func (r ResetRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	return nil
+}
+
+//ResetResponse represents gRPC endpoint reset response
+type ResetResponse struct {
+	Calls []*Call `description:"calls received before reset"`
+}
@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/viant/endly/testing/endpoint"
+	"github.com/viant/endly/udf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+//Server represents gRPC stub server serving services from proto descriptors
+type Server struct {
+	server   *grpc.Server
+	methods  map[string]*desc.MethodDescriptor
+	services []string
+	routes   Routes
+	calls    *endpoint.Calls
+	hits     *endpoint.Hits
+	running  int32
+}
+
+//Services returns served service names
+func (s *Server) Services() []string {
+	return s.services
+}
+
+//Routes returns server stub routes
+func (s *Server) Routes() Routes {
+	return s.routes
+}
+
+//Calls returns calls received since start or reset
+func (s *Server) Calls() []*Call {
+	var calls = s.calls.List()
+	var result = make([]*Call, len(calls))
+	for i, call := range calls {
+		result[i] = call.(*Call)
+	}
+	return result
+}
+
+//Hits returns route hit counts keyed by route name
+func (s *Server) Hits() map[string]int {
+	return s.hits.Counts()
+}
+
+//Reset clears received calls and route hit counts
+func (s *Server) Reset() {
+	s.calls.Reset()
+	s.hits.Reset()
+}
+
+//Shutdown stops server
+func (s *Server) Shutdown() {
+	if atomic.CompareAndSwapInt32(&s.running, 1, 0) {
+		s.server.Stop()
+	}
+}
+
+//handle receives request message(s), records the call and responds with the first matching route
+func (s *Server) handle(service interface{}, stream grpc.ServerStream) error {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	method, ok := s.methods[fullMethod]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %v", fullMethod)
+	}
+	var messages = make([]interface{}, 0)
+	for {
+		message := dynamic.NewMessage(method.GetInputType())
+		err := stream.RecvMsg(message)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		decoded, err := udf.ProtoMessageAsMap(message)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to decode %v message: %v", fullMethod, err)
+		}
+		messages = append(messages, decoded)
+		if !method.IsClientStreaming() {
+			break
+		}
+	}
+	var call = &Call{
+		Method:    strings.TrimPrefix(fullMethod, "/"),
+		Metadata:  make(map[string]string),
+		Message:   messages,
+		Timestamp: time.Now(),
+	}
+	if !method.IsClientStreaming() && len(messages) > 0 {
+		call.Message = messages[0]
+	}
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		for name, values := range md {
+			call.Metadata[name] = strings.Join(values, ",")
+		}
+	}
+	route, callData := s.routes.match(call)
+	if route != nil {
+		call.Route = route.Name
+		s.hits.Hit(route.Name)
+	}
+	s.calls.Add(call)
+	if route == nil {
+		return status.Errorf(codes.Unimplemented, "no route for %v", call.Method)
+	}
+	return route.respond(stream, method, callData)
+}
+
+//register registers services with stream handler for all methods, and server reflection
+func (s *Server) register(descriptors []*desc.FileDescriptor) error {
+	var files = &protoregistry.Files{}
+	for _, descriptor := range descriptors {
+		if err := registerFile(files, descriptor); err != nil {
+			return err
+		}
+		for _, service := range descriptor.GetServices() {
+			var serviceDesc = &grpc.ServiceDesc{
+				ServiceName: service.GetFullyQualifiedName(),
+				HandlerType: (*interface{})(nil),
+				Metadata:    descriptor.GetName(),
+			}
+			for _, method := range service.GetMethods() {
+				s.methods["/"+service.GetFullyQualifiedName()+"/"+method.GetName()] = method
+				serviceDesc.Streams = append(serviceDesc.Streams, grpc.StreamDesc{
+					StreamName:    method.GetName(),
+					Handler:       s.handle,
+					ServerStreams: method.IsServerStreaming(),
+					ClientStreams: method.IsClientStreaming(),
+				})
+			}
+			s.server.RegisterService(serviceDesc, struct{}{})
+			s.services = append(s.services, service.GetFullyQualifiedName())
+		}
+	}
+	sort.Strings(s.services)
+	rpb.RegisterServerReflectionServer(s.server, reflection.NewServer(reflection.ServerOptions{
+		Services:           s.server,
+		DescriptorResolver: files,
+	}))
+	return nil
+}
+
+//registerFile registers file descriptor with its dependencies
+func registerFile(files *protoregistry.Files, descriptor *desc.FileDescriptor) error {
+	if _, err := files.FindFileByPath(descriptor.GetName()); err == nil {
+		return nil
+	}
+	for _, dependency := range descriptor.GetDependencies() {
+		if err := registerFile(files, dependency); err != nil {
+			return err
+		}
+	}
+	file, err := protodesc.NewFile(descriptor.AsFileDescriptorProto(), files)
+	if err != nil {
+		return fmt.Errorf("failed to build %v descriptor, %v", descriptor.GetName(), err)
+	}
+	return files.RegisterFile(file)
+}
+
+//validateRoutes checks if route methods are served
+func (s *Server) validateRoutes() error {
+	for _, route := range s.routes {
+		if _, ok := s.methods["/"+route.Method]; !ok {
+			return fmt.Errorf("invalid route %v, unknown method: %v", route.Name, route.Method)
+		}
+	}
+	return nil
+}
+
+//StartServer starts gRPC stub server serving services from proto descriptors with supplied routes
+func StartServer(port int, descriptors []*desc.FileDescriptor, routes Routes) (*Server, error) {
+	var server = &Server{
+		server:   grpc.NewServer(),
+		methods:  make(map[string]*desc.MethodDescriptor),
+		services: make([]string, 0),
+		routes:   routes,
+		calls:    &endpoint.Calls{},
+		hits:     endpoint.NewHits(routes.Names()...),
+	}
+	if err := server.register(descriptors); err != nil {
+		return nil, err
+	}
+	if err := server.validateRoutes(); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start grpc server on port %v, %v", port, err)
+	}
+	server.running = 1
+	go func() {
+		_ = server.server.Serve(listener)
+		atomic.StoreInt32(&server.running, 0)
+	}()
+	return server, nil
+}
@@ -0,0 +1,171 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/udf"
+	"github.com/viant/toolbox/url"
+	"path"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	//ServiceID represents gRPC endpoint service id.
+	ServiceID = "grpc/endpoint"
+)
+
+//service represents gRPC endpoint service, that serves stub routes for services defined in proto files
+type service struct {
+	*endly.AbstractService
+	servers map[int]*Server
+}
+
+func (s *service) shutdown(context *endly.Context, req *ShutdownRequest) (interface{}, error) {
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	server, ok := s.servers[req.Port]
+	if !ok {
+		return nil, fmt.Errorf("endpoint at %v, not found", req.Port)
+	}
+	server.Shutdown()
+	delete(s.servers, req.Port)
+	serviceState := s.State()
+	serviceState.Delete(ServiceID + ":" + strconv.Itoa(req.Port))
+	return &struct{}{}, nil
+}
+
+func (s *service) listen(context *endly.Context, request *ListenRequest) (*ListenResponse, error) {
+	key := ServiceID + ":" + strconv.Itoa(request.Port)
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	var serviceState = s.State()
+	if value := serviceState.Get(key); value != nil {
+		if response, ok := value.(*ListenResponse); ok {
+			if server, ok := s.servers[request.Port]; ok {
+				response.Hits = server.Hits()
+			}
+			return response, nil
+		}
+	}
+	importPath, files := protoFiles(context, request)
+	descriptors, err := udf.ParseProtoFiles(importPath, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto files: %v, %v", files, err)
+	}
+	server, err := StartServer(request.Port, descriptors, request.Routes)
+	if err != nil {
+		return nil, err
+	}
+	s.servers[request.Port] = server
+	var response = &ListenResponse{
+		Services: server.Services(),
+		Hits:     server.Hits(),
+	}
+	serviceState.Put(key, response)
+	return response, nil
+}
+
+//protoFiles returns import path and proto files relative to it
+func protoFiles(context *endly.Context, request *ListenRequest) (string, []string) {
+	var files = make([]string, len(request.ProtoFiles))
+	for i, file := range request.ProtoFiles {
+		files[i] = url.NewResource(context.Expand(file)).ParsedURL.Path
+	}
+	importPath := path.Dir(files[0])
+	if request.ImportPath != "" {
+		importPath = url.NewResource(context.Expand(request.ImportPath)).ParsedURL.Path
+	}
+	for i, file := range files {
+		if relative, err := filepath.Rel(importPath, file); err == nil {
+			files[i] = relative
+		}
+	}
+	return importPath, files
+}
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "listen",
+		RequestInfo: &endly.ActionInfo{
+			Description: "start gRPC endpoint",
+		},
+		RequestProvider: func() interface{} {
+			return &ListenRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &ListenResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*ListenRequest); ok {
+				return s.listen(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	},
+		&endly.Route{
+			Action: "assert",
+			RequestInfo: &endly.ActionInfo{
+				Description: "validate calls received since listen or reset",
+			},
+			RequestProvider: func() interface{} {
+				return &AssertRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &AssertResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*AssertRequest); ok {
+					return s.assert(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "reset",
+			RequestInfo: &endly.ActionInfo{
+				Description: "clear received calls and route hit counts",
+			},
+			RequestProvider: func() interface{} {
+				return &ResetRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &ResetResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*ResetRequest); ok {
+					return s.reset(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "shutdown",
+			RequestInfo: &endly.ActionInfo{
+				Description: "stop gRPC endpoint",
+			},
+			RequestProvider: func() interface{} {
+				return &ShutdownRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &struct{}{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*ShutdownRequest); ok {
+					return s.shutdown(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		})
+}
+
+//New creates a new gRPC endpoint service, to stub services defined in proto files
+func New() endly.Service {
+	var result = &service{
+		servers:         make(map[int]*Server),
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,186 @@
+package grpc_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	endpoint "github.com/viant/endly/testing/endpoint/grpc"
+	runner "github.com/viant/endly/testing/runner/grpc"
+	"github.com/viant/toolbox"
+	"path"
+	"testing"
+)
+
+func TestGRPCEndpointService_Run(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := manager.Service(endpoint.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	protoFile := path.Join(parent, "../../../test/grpc/greeter.proto")
+	serviceResponse := service.Run(context, &endpoint.ListenRequest{
+		Port:       8931,
+		ProtoFiles: []string{protoFile},
+		Routes: endpoint.Routes{
+			{
+				Name:    "unknown",
+				Method:  "greeter.Greeter/SayHello",
+				Message: map[string]interface{}{"name": "Unknown"},
+				Status:  &endpoint.Status{Code: 5, Message: "$request.message.name not found"},
+			},
+			{
+				Method:   "greeter.Greeter.SayHello",
+				Metadata: map[string]string{"Authorization": "/^Bearer /"},
+				Response: map[string]interface{}{"message": "Hello $request.message.name"},
+			},
+			{
+				Method: "greeter.Greeter/StreamHello",
+				Response: []interface{}{
+					map[string]interface{}{"message": "Hello $request.message.name 0"},
+					map[string]interface{}{"message": "Hello $request.message.name 1"},
+				},
+			},
+		},
+	})
+	if !assert.Equal(t, "", serviceResponse.Error) {
+		return
+	}
+	defer service.Run(context, &endpoint.ShutdownRequest{Port: 8931})
+	listenResponse, ok := serviceResponse.Response.(*endpoint.ListenResponse)
+	if assert.True(t, ok) {
+		assert.Equal(t, []string{"greeter.Greeter"}, listenResponse.Services)
+	}
+
+	grpcRunner, err := manager.Service(runner.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		description string
+		request     *runner.CallRequest
+		expect      interface{}
+		hasError    bool
+	}{
+		{
+			description: "templated unary response with proto files",
+			request: &runner.CallRequest{
+				Method:     "greeter.Greeter/SayHello",
+				ProtoFiles: []string{protoFile},
+				Metadata:   map[string]string{"authorization": "Bearer abc"},
+				Request:    map[string]interface{}{"name": "Bob"},
+			},
+			expect: map[string]interface{}{"message": "Hello Bob"},
+		},
+		{
+			description: "templated stream response with server reflection",
+			request: &runner.CallRequest{
+				Method:  "greeter.Greeter/StreamHello",
+				Request: map[string]interface{}{"name": "Ann"},
+			},
+			expect: []interface{}{
+				map[string]interface{}{"message": "Hello Ann 0"},
+				map[string]interface{}{"message": "Hello Ann 1"},
+			},
+		},
+		{
+			description: "route error status",
+			request: &runner.CallRequest{
+				Method:  "greeter.Greeter/SayHello",
+				Request: map[string]interface{}{"name": "Unknown"},
+			},
+			hasError: true,
+		},
+		{
+			description: "no matching route",
+			request: &runner.CallRequest{
+				Method:  "greeter.Greeter/SayHello",
+				Request: map[string]interface{}{"name": "Bob"},
+			},
+			hasError: true,
+		},
+	}
+	for _, useCase := range useCases {
+		useCase.request.Address = "127.0.0.1:8931"
+		serviceResponse := grpcRunner.Run(context, useCase.request)
+		if useCase.hasError {
+			assert.NotEqual(t, "", serviceResponse.Error, useCase.description)
+			continue
+		}
+		if !assert.Equal(t, "", serviceResponse.Error, useCase.description) {
+			continue
+		}
+		response, ok := serviceResponse.Response.(*runner.CallResponse)
+		if assert.True(t, ok, useCase.description) {
+			assert.EqualValues(t, useCase.expect, response.Response, useCase.description)
+		}
+	}
+
+	serviceResponse = service.Run(context, &endpoint.ListenRequest{Port: 8931, ProtoFiles: []string{protoFile}})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		listenResponse := serviceResponse.Response.(*endpoint.ListenResponse)
+		assert.Equal(t, map[string]int{"unknown": 1, "greeter.Greeter/SayHello": 1, "greeter.Greeter/StreamHello": 1}, listenResponse.Hits)
+	}
+
+	count := 4
+	serviceResponse = service.Run(context, &endpoint.AssertRequest{
+		Port:  8931,
+		Count: &count,
+		Expect: []interface{}{
+			map[string]interface{}{
+				"Method":   "greeter.Greeter/SayHello",
+				"Metadata": map[string]interface{}{"authorization": "Bearer abc"},
+				"Message":  map[string]interface{}{"name": "Bob"},
+				"Route":    "greeter.Greeter/SayHello",
+			},
+			map[string]interface{}{
+				"Method":  "greeter.Greeter/StreamHello",
+				"Message": map[string]interface{}{"name": "Ann"},
+			},
+			map[string]interface{}{
+				"Route": "unknown",
+			},
+			map[string]interface{}{
+				"Message": map[string]interface{}{"name": "Bob"},
+				"Route":   "",
+			},
+		},
+	})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response := serviceResponse.Response.(*endpoint.AssertResponse)
+		assert.Equal(t, 4, len(response.Calls))
+		assert.False(t, response.Validation.HasFailure(), response.Validation.Report())
+	}
+
+	serviceResponse = service.Run(context, &endpoint.AssertRequest{
+		Port:     8931,
+		AnyOrder: true,
+		Expect: []interface{}{
+			map[string]interface{}{"Method": "greeter.Greeter/StreamHello"},
+			map[string]interface{}{"Method": "greeter.Greeter/Unknown"},
+		},
+	})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response := serviceResponse.Response.(*endpoint.AssertResponse)
+		assert.Equal(t, 1, response.Validation.FailedCount)
+	}
+
+	serviceResponse = service.Run(context, &endpoint.ResetRequest{Port: 8931})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response := serviceResponse.Response.(*endpoint.ResetResponse)
+		assert.Equal(t, 4, len(response.Calls))
+	}
+	serviceResponse = service.Run(context, &endpoint.AssertRequest{Port: 8931, Count: &count})
+	if assert.Equal(t, "", serviceResponse.Error) {
+		response := serviceResponse.Response.(*endpoint.AssertResponse)
+		assert.Equal(t, 0, len(response.Calls))
+		assert.Equal(t, 1, response.Validation.FailedCount)
+	}
+
+	serviceResponse = service.Run(context, &endpoint.ListenRequest{
+		Port:       8932,
+		ProtoFiles: []string{protoFile},
+		Routes:     endpoint.Routes{{Method: "greeter.Greeter/Unknown"}},
+	})
+	assert.Contains(t, serviceResponse.Error, "unknown method")
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/viant/endly/testing/endpoint"
+	"github.com/viant/endly/udf"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+//stubRequestKey represents state key holding matched call data in route response templates
+const stubRequestKey = "request"
+
+//Route represents method stub route, call has to match all route predicates
+type Route struct {
+	Name      string                 `description:"route name used for hit counts, method by default"`
+	Method    string                 `required:"true" description:"matching method i.e. greeter.Greeter/SayHello or greeter.Greeter.SayHello"`
+	Metadata  map[string]string      `description:"matching metadata values, compared with endpoint.MatchValue"`
+	Message   map[string]interface{} `description:"matching request message values keyed by field path i.e. user.id, compared with endpoint.MatchValue"`
+	Header    map[string]string      `description:"response header metadata"`
+	Response  interface{}            `description:"response message template, slice of messages for server streaming method, $request.method, $request.metadata and $request.message are expanded"`
+	Status    *Status                `description:"error status returned instead of response"`
+	LatencyMs int                    `description:"response delay in ms"`
+}
+
+//Status represents route error status
+type Status struct {
+	Code    int `description:"gRPC status code, 2 (Unknown) by default"`
+	Message string
+}
+
+//Init initialises route
+func (r *Route) Init() {
+	r.Method = methodName(r.Method)
+	if r.Name == "" {
+		r.Name = r.Method
+	}
+	if r.Status != nil && r.Status.Code == 0 {
+		r.Status.Code = int(codes.Unknown)
+	}
+}
+
+//Validate checks if route is valid
+func (r *Route) Validate() error {
+	if r.Method == "" {
+		return errors.New("route method was empty")
+	}
+	if strings.Count(r.Method, "/") != 1 {
+		return fmt.Errorf("invalid route %v method: %v, expected service/method", r.Name, r.Method)
+	}
+	return nil
+}
+
+//methodName returns method name in service/method format
+func methodName(method string) string {
+	method = strings.Trim(method, "/")
+	if method == "" || strings.Contains(method, "/") {
+		return method
+	}
+	if index := strings.LastIndex(method, "."); index != -1 {
+		return method[:index] + "/" + method[index+1:]
+	}
+	return method
+}
+
+//match returns true if call matches route predicates
+func (r *Route) match(call *Call, callData data.Map) bool {
+	if r.Method != call.Method {
+		return false
+	}
+	for name, expected := range r.Metadata {
+		if actual, has := call.Metadata[strings.ToLower(name)]; !has || !endpoint.MatchValue(expected, actual) {
+			return false
+		}
+	}
+	if len(r.Message) > 0 {
+		message, ok := callData.GetValue("message")
+		if !ok || !toolbox.IsMap(message) {
+			return false
+		}
+		messageData := data.Map(toolbox.AsMap(message))
+		for path, expected := range r.Message {
+			if actual, has := messageData.GetValue(path); !has || !endpoint.MatchValue(expected, actual) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//respond sends route response messages expanded with matched call data, or route error status
+func (r *Route) respond(stream grpc.ServerStream, method *desc.MethodDescriptor, callData data.Map) error {
+	if r.LatencyMs > 0 {
+		time.Sleep(time.Duration(r.LatencyMs) * time.Millisecond)
+	}
+	var state = data.NewMap()
+	state.Put(stubRequestKey, callData)
+	if len(r.Header) > 0 {
+		var header = make(map[string]string)
+		for name, value := range r.Header {
+			header[name] = state.ExpandAsText(value)
+		}
+		if err := stream.SetHeader(metadata.New(header)); err != nil {
+			return err
+		}
+	}
+	if r.Status != nil {
+		return status.Error(codes.Code(r.Status.Code), state.ExpandAsText(r.Status.Message))
+	}
+	var response = state.Expand(r.Response)
+	var responses = []interface{}{response}
+	if method.IsServerStreaming() && toolbox.IsSlice(response) {
+		responses = toolbox.AsSlice(response)
+	}
+	for _, item := range responses {
+		if item == nil {
+			item = map[string]interface{}{}
+		}
+		message, err := udf.NewProtoMessage(method.GetOutputType(), item)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create route %v response: %v", r.Name, err)
+		}
+		if err = stream.SendMsg(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Routes represents stub routes, the first matching route handles a call
+type Routes []*Route
+
+//Init initialises routes
+func (r Routes) Init() error {
+	var names = make(map[string]bool)
+	for _, route := range r {
+		route.Init()
+		if err := route.Validate(); err != nil {
+			return err
+		}
+		if names[route.Name] {
+			return fmt.Errorf("duplicate route: %v", route.Name)
+		}
+		names[route.Name] = true
+	}
+	return nil
+}
+
+//Names returns route names
+func (r Routes) Names() []string {
+	var result = make([]string, len(r))
+	for i, route := range r {
+		result[i] = route.Name
+	}
+	return result
+}
+
+//match returns the first route matching the call with call data, or nil if no route matched
+func (r Routes) match(call *Call) (*Route, data.Map) {
+	callData := call.asData()
+	for _, route := range r {
+		if !route.match(call, callData) {
+			continue
+		}
+		return route, callData
+	}
+	return nil, nil
+}
@@ -0,0 +1,54 @@
+package endpoint
+
+import "sync"
+
+//Hits represents stub route hit counts keyed by route name
+type Hits struct {
+	counts map[string]int
+	mux    sync.Mutex
+}
+
+//Hit increments route hit count
+func (h *Hits) Hit(name string) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.counts[name]++
+}
+
+//Counts returns hit counts
+func (h *Hits) Counts() map[string]int {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	var result = make(map[string]int)
+	for name, count := range h.counts {
+		result[name] = count
+	}
+	return result
+}
+
+//Reset sets all hit counts to zero
+func (h *Hits) Reset() {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	for name := range h.counts {
+		h.counts[name] = 0
+	}
+}
+
+//Init replaces tracked route names, counts of still tracked routes are kept
+func (h *Hits) Init(names ...string) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	var counts = make(map[string]int)
+	for _, name := range names {
+		counts[name] = h.counts[name]
+	}
+	h.counts = counts
+}
+
+//NewHits creates route hit counts for supplied route names
+func NewHits(names ...string) *Hits {
+	var result = &Hits{}
+	result.Init(names...)
+	return result
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/viant/toolbox"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//Call represents HTTP request received by the endpoint
+type Call struct {
+	Method    string
+	URL       string
+	Header    map[string]string `description:"header values, multiple values are comma separated"`
+	Body      string
+	Route     string `json:",omitempty" description:"matched stub route name"`
+	Timestamp time.Time
+}
+
+//AsMap returns call as map with decoded JSON body for assertion
+func (c *Call) AsMap() map[string]interface{} {
+	var body interface{} = c.Body
+	if decoded, err := toolbox.JSONToInterface(c.Body); err == nil && (toolbox.IsMap(decoded) || toolbox.IsSlice(decoded)) {
+		body = decoded
+	}
+	var header = make(map[string]interface{})
+	for name, value := range c.Header {
+		header[name] = value
+	}
+	return map[string]interface{}{
+		"Method": c.Method,
+		"URL":    c.URL,
+		"Header": header,
+		"Body":   body,
+		"Route":  c.Route,
+	}
+}
+
+//newCall creates a call from HTTP request, request body is restored after reading
+func newCall(request *http.Request) (*Call, error) {
+	var call = &Call{
+		Method:    request.Method,
+		URL:       request.URL.String(),
+		Header:    make(map[string]string),
+		Timestamp: time.Now(),
+	}
+	for name, values := range request.Header {
+		call.Header[name] = strings.Join(values, ",")
+	}
+	if request.Body != nil {
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body %v, %v", request.URL, err)
+		}
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		call.Body = string(body)
+	}
+	return call, nil
+}
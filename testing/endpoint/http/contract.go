@@ -2,6 +2,8 @@ package http
 
 import (
 	"errors"
+	"github.com/viant/assertly"
+	"github.com/viant/toolbox/bridge"
 	"sync"
 )
 
@@ -11,13 +13,15 @@ type ListenRequest struct {
 	Rotate           bool
 	RequestTemplate  string   `description:"request file loading template, default: %02d-req.json"`
 	ResponseTemplate string   `description:"response file loading template, default: %02d-resp.json"`
-	BaseDirectory    string   `required:"true" description:"location with replay files (could be generate by https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L81"`
+	BaseDirectory    string   `description:"location with replay files (could be generate by https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L81"`
 	IndexKeys        []string `description:"recorded requests matching keys, by default: Method,URL,Body,Cookie,Content-Type"`
+	Routes           Routes   `description:"programmable stub routes, matched before recorded trips"`
 }
 
 //ListenResponse represents HTTP endpoint listen response with indexed trips
 type ListenResponse struct {
 	Trips map[string]*HTTPResponses
+	Hits  map[string]int `description:"stub route hit counts keyed by route name"`
 }
 
 func (r *ListenRequest) Init() error {
@@ -35,6 +39,9 @@ func (r ListenRequest) Validate() error {
 	if r.Port == 0 {
 		return errors.New("port was empty")
 	}
+	if r.BaseDirectory == "" && len(r.Routes) == 0 {
+		return errors.New("baseDirectory and routes were empty")
+	}
 	return nil
 }
 
@@ -48,6 +55,7 @@ func (r ListenRequest) AsHTTPServerTrips() *HTTPServerTrips {
 		BaseDirectory: r.BaseDirectory,
 		Trips:         make(map[string]*HTTPResponses),
 		IndexKeys:     r.IndexKeys,
+		Routes:        r.Routes,
 		Mutex:         &sync.Mutex{},
 	}
 }
@@ -89,3 +97,109 @@ func (r AppendRequest) AsHTTPServerTrips(rotate bool, indexKeys []string) *HTTPS
 		Mutex:         &sync.Mutex{},
 	}
 }
+
+//AssertRequest represents HTTP endpoint received calls assert request
+type AssertRequest struct {
+	Port     int
+	Expect   []interface{} `description:"expected calls with Method, URL, Header, Body (decoded if JSON) or Route, validated with assertly"`
+	Count    *int          `description:"expected number of calls received since listen or reset"`
+	AnyOrder bool          `description:"flag to match expected calls in any order, received order by default"`
+}
+
+//Validate checks if request is valid.
+func (r AssertRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	return nil
+}
+
+//AssertResponse represents HTTP endpoint assert response with received calls
+type AssertResponse struct {
+	Calls      []*Call
+	Validation *assertly.Validation
+}
+
+//Assertion returns validation slice
+func (r *AssertResponse) Assertion() []*assertly.Validation {
+	if r == nil || r.Validation == nil {
+		return []*assertly.Validation{}
+	}
+	return []*assertly.Validation{r.Validation}
+}
+
+//ResetRequest represents HTTP endpoint received calls and route hit counts reset request
+type ResetRequest struct {
+	Port int
+}
+
+//Validate checks if request is valid.
+func (r ResetRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	return nil
+}
+
+//ResetResponse represents HTTP endpoint reset response
+type ResetResponse struct {
+	Calls []*Call `description:"calls received before reset"`
+}
+
+//RecordRequest represents HTTP recording proxy start request
+type RecordRequest struct {
+	Port             int
+	Upstreams        []string `description:"upstream base URLs, request is proxied to the upstream with the longest matching URL path, absolute request URL is proxied as is (forward proxy)"`
+	BaseDirectory    string   `required:"true" description:"location for recorded trips consumed by listen"`
+	RequestTemplate  string   `description:"request file template, default: %02d-req.json"`
+	ResponseTemplate string   `description:"response file template, default: %02d-resp.json"`
+	RedactHeaders    []string `description:"request and response header names recorded with *** value, i.e. Authorization"`
+	IncludePaths     []string `description:"URL path regular expressions to record, all paths by default"`
+	ExcludePaths     []string `description:"URL path regular expressions to skip"`
+}
+
+//Init initialises request
+func (r *RecordRequest) Init() error {
+	if r.RequestTemplate == "" {
+		r.RequestTemplate = DefaultRequestTemplate
+	}
+	if r.ResponseTemplate == "" {
+		r.ResponseTemplate = DefaultResponseTemplate
+	}
+	return nil
+}
+
+//Validate checks if request is valid.
+func (r RecordRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	if r.BaseDirectory == "" {
+		return errors.New("baseDirectory was empty")
+	}
+	return nil
+}
+
+//RecordResponse represents HTTP recording proxy start response
+type RecordResponse struct {
+	BaseDirectory string
+}
+
+//StopRequest represents HTTP recording proxy stop request
+type StopRequest struct {
+	Port int
+}
+
+//Validate checks if request is valid.
+func (r StopRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	return nil
+}
+
+//StopResponse represents HTTP recording proxy stop response with recorded trips
+type StopResponse struct {
+	BaseDirectory string
+	Trips         []*bridge.RecordedHttpTrip
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"fmt"
+	"github.com/viant/endly/testing/endpoint"
 	"github.com/viant/endly/util"
 	"github.com/viant/toolbox"
 	"log"
@@ -15,6 +16,8 @@ type httpHandler struct {
 	running   int32
 	handler   func(writer http.ResponseWriter, request *http.Request)
 	thinkTime time.Duration
+	calls     *endpoint.Calls
+	hits      *endpoint.Hits
 }
 
 const (
@@ -31,13 +34,30 @@ func (h *httpHandler) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 
 func getServerHandler(httpServer *http.Server, httpHandler *httpHandler, trips *HTTPServerTrips) func(writer http.ResponseWriter, request *http.Request) {
 	return func(writer http.ResponseWriter, request *http.Request) {
-		trips.Mutex.Lock()
-		defer trips.Mutex.Unlock()
 		if atomic.LoadInt32(&httpHandler.running) == 0 {
 			return
 		}
+		call, err := newCall(request)
+		if err != nil {
+			http.Error(writer, fmt.Sprintf("%v", err), http.StatusInternalServerError)
+			return
+		}
+		route, requestData := trips.Routes.match(request, []byte(call.Body))
+		if route != nil {
+			call.Route = route.Name
+			httpHandler.hits.Hit(route.Name)
+		}
+		httpHandler.calls.Add(call)
+		if route != nil {
+			if err = route.respond(writer, requestData); err != nil {
+				log.Print(err)
+			}
+			return
+		}
+		trips.Mutex.Lock()
+		defer trips.Mutex.Unlock()
 
-		var key, err = buildKeyValue(trips.IndexKeys, request)
+		key, err := buildKeyValue(trips.IndexKeys, request)
 		if err != nil {
 			http.Error(writer, fmt.Sprintf("%v", err), http.StatusInternalServerError)
 			return
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox/url"
+)
+
+//recording represents started recording proxy with its request
+type recording struct {
+	*Recorder
+	request *RecordRequest
+}
+
+func (s *service) record(context *endly.Context, request *RecordRequest) (*RecordResponse, error) {
+	state := context.State()
+	request.BaseDirectory = url.NewResource(state.ExpandAsText(request.BaseDirectory)).ParsedURL.Path
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	if _, ok := s.recordings[request.Port]; ok {
+		return nil, fmt.Errorf("recorder at %v is already running", request.Port)
+	}
+	recorder, err := StartRecording(request)
+	if err != nil {
+		return nil, err
+	}
+	s.recordings[request.Port] = &recording{Recorder: recorder, request: request}
+	return &RecordResponse{BaseDirectory: request.BaseDirectory}, nil
+}
+
+func (s *service) stop(context *endly.Context, request *StopRequest) (*StopResponse, error) {
+	s.Mutex().Lock()
+	recording, ok := s.recordings[request.Port]
+	delete(s.recordings, request.Port)
+	s.Mutex().Unlock()
+	if !ok {
+		return nil, fmt.Errorf("recorder at %v, not found", request.Port)
+	}
+	if err := recording.Stop(context.Background()); err != nil {
+		return nil, err
+	}
+	response := &StopResponse{
+		BaseDirectory: recording.request.BaseDirectory,
+		Trips:         recording.Trips(),
+	}
+	err := WriteTrips(response.Trips, recording.request.BaseDirectory, recording.request.RequestTemplate, recording.request.ResponseTemplate)
+	return response, err
+}
@@ -0,0 +1,280 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/util"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/bridge"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+//upstream represents recording proxy upstream
+type upstream struct {
+	path string
+	URL  *url.URL
+}
+
+//Recorder represents HTTP recording proxy, it captures trips in the format consumed by listen
+type Recorder struct {
+	server        *http.Server
+	proxy         *httputil.ReverseProxy
+	upstreams     []*upstream
+	includePaths  []*regexp.Regexp
+	excludePaths  []*regexp.Regexp
+	redactHeaders map[string]bool
+	trips         []*bridge.RecordedHttpTrip
+	mux           sync.Mutex
+	running       int32
+}
+
+//recordingWriter captures proxied response
+type recordingWriter struct {
+	http.ResponseWriter
+	code int
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+//ServeHTTP proxies request to matching upstream or requested URL in forward proxy mode, and captures the trip
+func (r *Recorder) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if r.match(request.URL) == nil && request.URL.Host == "" {
+		http.Error(writer, fmt.Sprintf("no upstream for %v", request.URL), http.StatusBadGateway)
+		return
+	}
+	var body []byte
+	if request.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(request.Body); err != nil {
+			http.Error(writer, fmt.Sprintf("failed to read body %v, %v", request.URL, err), http.StatusInternalServerError)
+			return
+		}
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	requestHeader, requestBody := decodeBody(r.redact(request.Header), body)
+	var recordedRequest = &bridge.HttpRequest{
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Header: requestHeader,
+		Body:   util.AsPayload(requestBody),
+	}
+	recording := &recordingWriter{ResponseWriter: writer}
+	r.proxy.ServeHTTP(recording, request)
+	if !r.isRecorded(request.URL.Path) {
+		return
+	}
+	responseHeader, responseBody := decodeBody(r.redact(writer.Header()), recording.body.Bytes())
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.trips = append(r.trips, &bridge.RecordedHttpTrip{
+		Request: recordedRequest,
+		Response: &bridge.HttpResponse{
+			Code:   recording.code,
+			Header: responseHeader,
+			Body:   util.AsPayload(responseBody),
+		},
+	})
+}
+
+//decodeBody returns gzip decoded body with header without content encoding and length, so that recorded trip replays as plain payload, undecodable body is returned as is
+func decodeBody(header http.Header, body []byte) (http.Header, []byte) {
+	if len(body) == 0 || !strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		return header, body
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return header, body
+	}
+	defer reader.Close()
+	decoded, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return header, body
+	}
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+	return header, decoded
+}
+
+//match returns upstream with the longest path prefix matching URL path
+func (r *Recorder) match(URL *url.URL) *upstream {
+	var result *upstream
+	for _, candidate := range r.upstreams {
+		if !strings.HasPrefix(URL.Path, candidate.path) {
+			continue
+		}
+		if result == nil || len(candidate.path) > len(result.path) {
+			result = candidate
+		}
+	}
+	return result
+}
+
+func (r *Recorder) direct(request *http.Request) {
+	if upstream := r.match(request.URL); upstream != nil {
+		request.URL.Scheme = upstream.URL.Scheme
+		request.URL.Host = upstream.URL.Host
+		request.Host = upstream.URL.Host
+	}
+}
+
+func (r *Recorder) isRecorded(URLPath string) bool {
+	for _, expr := range r.excludePaths {
+		if expr.MatchString(URLPath) {
+			return false
+		}
+	}
+	if len(r.includePaths) == 0 {
+		return true
+	}
+	for _, expr := range r.includePaths {
+		if expr.MatchString(URLPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recorder) redact(header http.Header) http.Header {
+	var result = make(http.Header)
+	for name, values := range header {
+		if r.redactHeaders[strings.ToLower(name)] {
+			values = []string{endly.RedactedMask}
+		}
+		result[name] = append([]string{}, values...)
+	}
+	return result
+}
+
+//Trips returns captured trips
+func (r *Recorder) Trips() []*bridge.RecordedHttpTrip {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	var result = make([]*bridge.RecordedHttpTrip, len(r.trips))
+	copy(result, r.trips)
+	return result
+}
+
+//Stop stops recording proxy
+func (r *Recorder) Stop(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&r.running, 1, 0) {
+		return nil
+	}
+	return r.server.Shutdown(ctx)
+}
+
+//WriteTrips writes captured trips to base directory with request and response templates, stale trip files from previous recording are removed
+func WriteTrips(trips []*bridge.RecordedHttpTrip, baseDirectory, requestTemplate, responseTemplate string) error {
+	if err := os.MkdirAll(baseDirectory, 0755); err != nil {
+		return fmt.Errorf("failed to create %v, %v", baseDirectory, err)
+	}
+	for i, trip := range trips {
+		if err := writeTripFile(path.Join(baseDirectory, fmt.Sprintf(requestTemplate, i)), trip.Request); err != nil {
+			return err
+		}
+		if err := writeTripFile(path.Join(baseDirectory, fmt.Sprintf(responseTemplate, i)), trip.Response); err != nil {
+			return err
+		}
+	}
+	for i := len(trips); ; i++ {
+		requestFile := path.Join(baseDirectory, fmt.Sprintf(requestTemplate, i))
+		responseFile := path.Join(baseDirectory, fmt.Sprintf(responseTemplate, i))
+		if !toolbox.FileExists(requestFile) && !toolbox.FileExists(responseFile) {
+			break
+		}
+		_ = os.Remove(requestFile)
+		_ = os.Remove(responseFile)
+	}
+	return nil
+}
+
+func writeTripFile(filename string, source interface{}) error {
+	data, err := json.MarshalIndent(source, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %v, %v", filename, err)
+	}
+	return nil
+}
+
+//StartRecording starts HTTP recording proxy for supplied request
+func StartRecording(request *RecordRequest) (*Recorder, error) {
+	var recorder = &Recorder{
+		upstreams:     make([]*upstream, 0),
+		redactHeaders: make(map[string]bool),
+		trips:         make([]*bridge.RecordedHttpTrip, 0),
+		running:       1,
+	}
+	for _, upstreamURL := range request.Upstreams {
+		URL, err := url.Parse(upstreamURL)
+		if err != nil || URL.Host == "" {
+			return nil, fmt.Errorf("invalid upstream URL: %v", upstreamURL)
+		}
+		var upstreamPath = URL.Path
+		if upstreamPath == "" {
+			upstreamPath = "/"
+		}
+		recorder.upstreams = append(recorder.upstreams, &upstream{path: upstreamPath, URL: URL})
+	}
+	var err error
+	if recorder.includePaths, err = compileExpressions(request.IncludePaths); err != nil {
+		return nil, err
+	}
+	if recorder.excludePaths, err = compileExpressions(request.ExcludePaths); err != nil {
+		return nil, err
+	}
+	for _, name := range request.RedactHeaders {
+		recorder.redactHeaders[strings.ToLower(name)] = true
+	}
+	recorder.proxy = &httputil.ReverseProxy{Director: recorder.direct}
+	recorder.server = &http.Server{Addr: fmt.Sprintf(":%v", request.Port), Handler: recorder}
+
+	listener, err := net.Listen("tcp", recorder.server.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start http recorder on port %v, %v", request.Port, err)
+	}
+	go func() {
+		_ = recorder.server.Serve(listener)
+		atomic.StoreInt32(&recorder.running, 0)
+	}()
+	return recorder, nil
+}
+
+func compileExpressions(expressions []string) ([]*regexp.Regexp, error) {
+	var result = make([]*regexp.Regexp, 0)
+	for _, expression := range expressions {
+		expr, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path expression: %v, %v", expression, err)
+		}
+		result = append(result, expr)
+	}
+	return result, nil
+}
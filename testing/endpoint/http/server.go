@@ -2,6 +2,7 @@ package http
 
 import (
 	"fmt"
+	"github.com/viant/endly/testing/endpoint"
 	"net/http"
 	"sync"
 	"sync/atomic"
@@ -18,6 +19,7 @@ type Server struct {
 	http.Server
 	*httpHandler
 	trips            map[string]*HTTPResponses
+	routes           Routes
 	mux              sync.Mutex
 	rotate           bool
 	indexKeys        []string
@@ -25,6 +27,34 @@ type Server struct {
 	responseTemplate string
 }
 
+//Routes returns server stub routes
+func (s *Server) Routes() Routes {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.routes
+}
+
+//Calls returns requests received since server start or reset
+func (s *Server) Calls() []*Call {
+	var calls = s.httpHandler.calls.List()
+	var result = make([]*Call, len(calls))
+	for i, call := range calls {
+		result[i] = call.(*Call)
+	}
+	return result
+}
+
+//Hits returns route hit counts keyed by route name
+func (s *Server) Hits() map[string]int {
+	return s.httpHandler.hits.Counts()
+}
+
+//Reset clears received requests and route hit counts
+func (s *Server) Reset() {
+	s.httpHandler.calls.Reset()
+	s.httpHandler.hits.Reset()
+}
+
 func (s *Server) Append(trips *HTTPServerTrips) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
@@ -36,6 +66,11 @@ func (s *Server) Append(trips *HTTPServerTrips) {
 			trips.Trips[k] = v
 		}
 	}
+	if len(trips.Routes) == 0 {
+		trips.Routes = s.routes
+	}
+	s.routes = trips.Routes
+	s.httpHandler.hits.Init(trips.Routes.Names()...)
 	s.httpHandler.handler = getServerHandler(&s.Server, s.httpHandler, trips)
 }
 
@@ -48,6 +83,8 @@ func StartServer(port int, trips *HTTPServerTrips, reqTemplate, respTemplate str
 
 	var httpHandler = &httpHandler{
 		running: 1,
+		calls:   &endpoint.Calls{},
+		hits:    endpoint.NewHits(trips.Routes.Names()...),
 	}
 
 	server := &Server{
@@ -55,6 +92,7 @@ func StartServer(port int, trips *HTTPServerTrips, reqTemplate, respTemplate str
 		indexKeys:        trips.IndexKeys,
 		httpHandler:      httpHandler,
 		trips:            trips.Trips,
+		routes:           trips.Routes,
 		Server:           http.Server{Addr: fmt.Sprintf(":%v", port), Handler: httpHandler},
 		requestTemplate:  reqTemplate,
 		responseTemplate: respTemplate,
@@ -15,7 +15,8 @@ const (
 //service represents http endpoint service, that has ability to replay HTTP trips
 type service struct {
 	*endly.AbstractService
-	servers map[int]*Server
+	servers    map[int]*Server
+	recordings map[int]*recording
 }
 
 func (s *service) shutdown(context *endly.Context, req *ShutdownRequest) (interface{}, error) {
@@ -42,6 +43,9 @@ func (s *service) listen(context *endly.Context, request *ListenRequest) (*Liste
 	value := serviceState.Get(key)
 	if value != nil {
 		if response = value.(*ListenResponse); response != nil {
+			if server, ok := s.servers[request.Port]; ok {
+				response.Hits = server.Hits()
+			}
 			return response, nil
 		}
 	}
@@ -55,6 +59,7 @@ func (s *service) listen(context *endly.Context, request *ListenRequest) (*Liste
 	s.servers[request.Port] = server
 	response = &ListenResponse{
 		Trips: trips.Trips,
+		Hits:  server.Hits(),
 	}
 	serviceState.Put(key, response)
 	return response, nil
@@ -97,6 +102,78 @@ func (s *service) registerRoutes() {
 				return nil, fmt.Errorf("unsupported request type: %T", request)
 			},
 		},
+		&endly.Route{
+			Action: "assert",
+			RequestInfo: &endly.ActionInfo{
+				Description: "validate requests received since listen or reset",
+			},
+			RequestProvider: func() interface{} {
+				return &AssertRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &AssertResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*AssertRequest); ok {
+					return s.assert(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "reset",
+			RequestInfo: &endly.ActionInfo{
+				Description: "clear received requests and route hit counts",
+			},
+			RequestProvider: func() interface{} {
+				return &ResetRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &ResetResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*ResetRequest); ok {
+					return s.reset(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "record",
+			RequestInfo: &endly.ActionInfo{
+				Description: "start HTTP recording proxy capturing trips for listen",
+			},
+			RequestProvider: func() interface{} {
+				return &RecordRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &RecordResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*RecordRequest); ok {
+					return s.record(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "stop",
+			RequestInfo: &endly.ActionInfo{
+				Description: "stop HTTP recording proxy, write and return recorded trips",
+			},
+			RequestProvider: func() interface{} {
+				return &StopRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &StopResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*StopRequest); ok {
+					return s.stop(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
 		&endly.Route{
 			Action: "shutdown",
 			RequestInfo: &endly.ActionInfo{
@@ -123,6 +200,7 @@ func (s *service) registerRoutes() {
 func New() endly.Service {
 	var result = &service{
 		servers:         make(map[int]*Server),
+		recordings:      make(map[int]*recording),
 		AbstractService: endly.NewAbstractService(ServiceID),
 	}
 	result.AbstractService.Service = result
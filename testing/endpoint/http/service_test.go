@@ -1,11 +1,16 @@
 package http_test
 
 import (
+	"compress/gzip"
+	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/endly"
 	endpoint "github.com/viant/endly/testing/endpoint/http"
 	"github.com/viant/toolbox"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"path"
 	"strings"
 	"testing"
@@ -60,11 +65,372 @@ func TestHTTPEndpointService_Run_WithError(t *testing.T) {
 		})
 		assert.True(t, response.Error != "")
 	}
-	{ //no port error
+	{ //no base directory and routes error
 		response := service.Run(context, &endpoint.ListenRequest{
 			Port: 1,
 		})
-		assert.True(t, response.Error == "", response.Error)
+		assert.True(t, strings.Contains(response.Error, "baseDirectory and routes were empty"), response.Error)
+	}
+
+}
+
+func TestHTTPEndpointService_Routes(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+
+	listenRequest := &endpoint.ListenRequest{
+		Port: 7719,
+		Routes: endpoint.Routes{
+			{
+				Name:   "getUser",
+				Method: "get",
+				Path:   "/users/{id}",
+				Query:  map[string]string{"fields": "/name|all/"},
+				Response: &endpoint.StubResponse{
+					Header: map[string]string{"X-User": "$request.params.id"},
+					Body:   map[string]interface{}{"id": "$request.params.id", "fields": "$request.query.fields"},
+				},
+			},
+			{
+				Name:   "createAdmin",
+				Method: "POST",
+				Path:   "/users",
+				Body:   map[string]interface{}{"user.role": "admin"},
+				Response: &endpoint.StubResponse{
+					Code: 201,
+					Body: "created $request.body.user.name",
+				},
+			},
+			{
+				Name:      "unavailable",
+				Path:      "/fault/*",
+				LatencyMs: 10,
+				Fault:     &endpoint.Fault{Code: 503, Body: "unavailable"},
+			},
+		},
+	}
+	response := service.Run(context, listenRequest)
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	client := http.DefaultClient
+	var useCases = []struct {
+		description string
+		method      string
+		URL         string
+		body        string
+		expectCode  int
+		expectBody  string
+		expectUser  string
+	}{
+		{
+			description: "path params and query template",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/users/12?fields=name",
+			expectCode:  200,
+			expectBody:  `{"fields":"name","id":"12"}`,
+			expectUser:  "12",
+		},
+		{
+			description: "query predicate mismatch",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/users/12?fields=email",
+			expectCode:  404,
+		},
+		{
+			description: "JSON body predicate",
+			method:      "POST",
+			URL:         "http://127.0.0.1:7719/users",
+			body:        `{"user":{"name":"Bob","role":"admin"}}`,
+			expectCode:  201,
+			expectBody:  "created Bob",
+		},
+		{
+			description: "JSON body predicate mismatch",
+			method:      "POST",
+			URL:         "http://127.0.0.1:7719/users",
+			body:        `{"user":{"name":"Bob","role":"guest"}}`,
+			expectCode:  404,
+		},
+		{
+			description: "fault injection",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/fault/a/b",
+			expectCode:  503,
+			expectBody:  "unavailable",
+		},
+	}
+
+	for _, useCase := range useCases {
+		request, err := http.NewRequest(useCase.method, useCase.URL, strings.NewReader(useCase.body))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		response, err := client.Do(request)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		assert.Nil(t, err, useCase.description)
+		assert.Equal(t, useCase.expectCode, response.StatusCode, useCase.description)
+		if useCase.expectBody != "" {
+			assert.Equal(t, useCase.expectBody, strings.TrimSpace(string(body)), useCase.description)
+		}
+		assert.Equal(t, useCase.expectUser, response.Header.Get("X-User"), useCase.description)
+	}
+
+	response = service.Run(context, listenRequest)
+	if listenResponse, ok := response.Response.(*endpoint.ListenResponse); assert.True(t, ok) {
+		assert.Equal(t, map[string]int{"getUser": 1, "createAdmin": 1, "unavailable": 1}, listenResponse.Hits)
+	}
+}
+
+func TestHTTPEndpointService_RoutesWithTrips(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+
+	listenRequest := &endpoint.ListenRequest{
+		Port:          7725,
+		BaseDirectory: path.Join(parent, "test", "send"),
+		Routes: endpoint.Routes{
+			{
+				Name:     "send2",
+				Method:   "POST",
+				Path:     "/send2",
+				Response: &endpoint.StubResponse{Code: 202, Body: "stubbed"},
+			},
+		},
+	}
+	response := service.Run(context, listenRequest)
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	if listenResponse, ok := response.Response.(*endpoint.ListenResponse); assert.True(t, ok) {
+		assert.Equal(t, 2, len(listenResponse.Trips))
+	}
+	client := http.DefaultClient
+	{ //recorded trip replay
+		response, err := client.Post("http://127.0.0.1:7725/send1", "", strings.NewReader("0123456789"))
+		if assert.Nil(t, err) {
+			assert.Equal(t, 200, response.StatusCode)
+		}
+	}
+	{ //stub route matched before recorded trip
+		response, err := client.Post("http://127.0.0.1:7725/send2", "", strings.NewReader("xc"))
+		if assert.Nil(t, err) {
+			body, _ := ioutil.ReadAll(response.Body)
+			_ = response.Body.Close()
+			assert.Equal(t, 202, response.StatusCode)
+			assert.Equal(t, "stubbed", strings.TrimSpace(string(body)))
+		}
+	}
+	response = service.Run(context, listenRequest)
+	if listenResponse, ok := response.Response.(*endpoint.ListenResponse); assert.True(t, ok) {
+		assert.Equal(t, map[string]int{"send2": 1}, listenResponse.Hits)
+	}
+}
+
+func TestHTTPEndpointService_Assert(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+
+	response := service.Run(context, &endpoint.ListenRequest{
+		Port: 7720,
+		Routes: endpoint.Routes{
+			{Name: "users", Path: "/users/*"},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
 	}
+	client := http.DefaultClient
+	_, err := client.Post("http://127.0.0.1:7720/users/1", "application/json", strings.NewReader(`{"name":"Bob"}`))
+	assert.Nil(t, err)
+	_, err = client.Get("http://127.0.0.1:7720/users/2")
+	assert.Nil(t, err)
 
+	two, one := 2, 1
+	var useCases = []struct {
+		description string
+		request     *endpoint.AssertRequest
+		expectFail  int
+	}{
+		{
+			description: "received order",
+			request: &endpoint.AssertRequest{
+				Count: &two,
+				Expect: []interface{}{
+					map[string]interface{}{"Method": "POST", "URL": "/users/1", "Body": map[string]interface{}{"name": "Bob"}, "Route": "users"},
+					map[string]interface{}{"Method": "GET", "URL": "/users/2"},
+				},
+			},
+		},
+		{
+			description: "received order mismatch",
+			request: &endpoint.AssertRequest{
+				Expect: []interface{}{
+					map[string]interface{}{"URL": "/users/2"},
+					map[string]interface{}{"URL": "/users/1"},
+				},
+			},
+			expectFail: 2,
+		},
+		{
+			description: "any order",
+			request: &endpoint.AssertRequest{
+				AnyOrder: true,
+				Expect: []interface{}{
+					map[string]interface{}{"URL": "/users/2"},
+					map[string]interface{}{"URL": "/users/1", "Header": map[string]interface{}{"Content-Type": "application/json"}},
+				},
+			},
+		},
+		{
+			description: "missing call and count mismatch",
+			request: &endpoint.AssertRequest{
+				Count:    &one,
+				AnyOrder: true,
+				Expect: []interface{}{
+					map[string]interface{}{"URL": "/users/3"},
+				},
+			},
+			expectFail: 2,
+		},
+	}
+	for _, useCase := range useCases {
+		useCase.request.Port = 7720
+		response := service.Run(context, useCase.request)
+		if !assert.Equal(t, "", response.Error, useCase.description) {
+			continue
+		}
+		assertResponse, ok := response.Response.(*endpoint.AssertResponse)
+		if !assert.True(t, ok, useCase.description) {
+			continue
+		}
+		assert.Equal(t, 2, len(assertResponse.Calls), useCase.description)
+		assert.Equal(t, useCase.expectFail, assertResponse.Validation.FailedCount, useCase.description)
+	}
+
+	response = service.Run(context, &endpoint.ResetRequest{Port: 7720})
+	if resetResponse, ok := response.Response.(*endpoint.ResetResponse); assert.True(t, ok) {
+		assert.Equal(t, 2, len(resetResponse.Calls))
+	}
+	response = service.Run(context, &endpoint.AssertRequest{Port: 7720})
+	if assertResponse, ok := response.Response.(*endpoint.AssertResponse); assert.True(t, ok) {
+		assert.Equal(t, 0, len(assertResponse.Calls))
+	}
+	response = service.Run(context, &endpoint.ResetRequest{Port: 7721})
+	assert.NotEqual(t, "", response.Error)
+}
+
+func TestHTTPEndpointService_Record(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+	baseDirectory, err := ioutil.TempDir("", "http_recording")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer os.RemoveAll(baseDirectory)
+
+	response := service.Run(context, &endpoint.ListenRequest{
+		Port: 7722,
+		Routes: endpoint.Routes{
+			{Path: "/users/{id}", Response: &endpoint.StubResponse{Body: "user $request.params.id"}},
+			{Path: "/health", Response: &endpoint.StubResponse{Body: "ok"}},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	response = service.Run(context, &endpoint.RecordRequest{
+		Port:          7723,
+		Upstreams:     []string{"http://127.0.0.1:7722/"},
+		BaseDirectory: baseDirectory,
+		RedactHeaders: []string{"Authorization"},
+		ExcludePaths:  []string{"^/health"},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	client := http.DefaultClient
+	for _, URI := range []string{"/users/1", "/health", "/users/2"} {
+		request, _ := http.NewRequest("GET", "http://127.0.0.1:7723"+URI, nil)
+		request.Header.Set("Authorization", "Bearer abcdef")
+		response, err := client.Do(request)
+		if assert.Nil(t, err, URI) {
+			assert.Equal(t, 200, response.StatusCode, URI)
+			_ = response.Body.Close()
+		}
+	}
+
+	response = service.Run(context, &endpoint.StopRequest{Port: 7723})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	stopResponse, ok := response.Response.(*endpoint.StopResponse)
+	if !assert.True(t, ok) || !assert.Equal(t, 2, len(stopResponse.Trips)) {
+		return
+	}
+	assert.Equal(t, "/users/1", stopResponse.Trips[0].Request.URL)
+	assert.Equal(t, "***", stopResponse.Trips[0].Request.Header.Get("Authorization"))
+	assert.Equal(t, "user 2", stopResponse.Trips[1].Response.Body)
+
+	response = service.Run(context, &endpoint.ListenRequest{
+		Port:          7724,
+		BaseDirectory: baseDirectory,
+		IndexKeys:     []string{endpoint.MethodKey, endpoint.URLKey},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	replayed, err := client.Get("http://127.0.0.1:7724/users/2")
+	if assert.Nil(t, err) {
+		body, _ := ioutil.ReadAll(replayed.Body)
+		assert.Equal(t, "user 2", string(body))
+	}
+	response = service.Run(context, &endpoint.StopRequest{Port: 7723})
+	assert.NotEqual(t, "", response.Error)
+}
+
+func TestStartRecording_Gzip(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "text/plain")
+		writer.Header().Set("Content-Encoding", "gzip")
+		compressed := gzip.NewWriter(writer)
+		_, _ = compressed.Write([]byte("compressed user"))
+		_ = compressed.Close()
+	}))
+	defer upstream.Close()
+
+	recorder, err := endpoint.StartRecording(&endpoint.RecordRequest{Port: 7726, Upstreams: []string{upstream.URL + "/"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer recorder.Stop(context.Background())
+
+	_, err = endpoint.StartRecording(&endpoint.RecordRequest{Port: 7726, Upstreams: []string{upstream.URL + "/"}})
+	assert.NotNil(t, err, "port already in use")
+
+	request, _ := http.NewRequest("GET", "http://127.0.0.1:7726/users/1", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	response, err := http.DefaultClient.Do(request)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_ = response.Body.Close()
+	assert.Equal(t, "gzip", response.Header.Get("Content-Encoding"))
+
+	trips := recorder.Trips()
+	if !assert.Equal(t, 1, len(trips)) {
+		return
+	}
+	assert.Equal(t, "compressed user", trips[0].Response.Body)
+	assert.Equal(t, "", trips[0].Response.Header.Get("Content-Encoding"))
+	assert.Equal(t, "", trips[0].Response.Header.Get("Content-Length"))
 }
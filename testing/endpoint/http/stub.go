@@ -0,0 +1,262 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/endly/testing/endpoint"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+//stubRequestKey represents state key holding matched request data in route response templates
+const stubRequestKey = "request"
+
+//Route represents programmable stub route, request has to match all route predicates
+type Route struct {
+	Name      string                 `description:"route name used for hit counts, method and path by default"`
+	Method    string                 `description:"matching HTTP method, any method if empty"`
+	Path      string                 `description:"matching URL path pattern, {name} matches path param, trailing * matches remaining path"`
+	Header    map[string]string      `description:"matching header values, compared with endpoint.MatchValue"`
+	Query     map[string]string      `description:"matching query parameter values, compared with endpoint.MatchValue"`
+	Body      map[string]interface{} `description:"matching JSON body values keyed by field path i.e. user.id, compared with endpoint.MatchValue"`
+	Response  *StubResponse          `description:"response template, $request.method, $request.path, $request.params, $request.query, $request.header and $request.body are expanded"`
+	LatencyMs int                    `description:"response delay in ms"`
+	Fault     *Fault                 `description:"fault injection"`
+}
+
+//StubResponse represents route response template
+type StubResponse struct {
+	Code   int `description:"status code, 200 by default"`
+	Header map[string]string
+	Body   interface{} `description:"text template or data encoded as JSON"`
+}
+
+//Fault represents route fault injection
+type Fault struct {
+	Rate  float64 `description:"fault probability from 0 to 1, 1 by default"`
+	Code  int     `description:"fault status code, 500 by default"`
+	Body  string
+	Abort bool `description:"flag to close connection without response"`
+}
+
+//Init initialises route
+func (r *Route) Init() {
+	r.Method = strings.ToUpper(r.Method)
+	if r.Name == "" {
+		r.Name = strings.TrimSpace(r.Method + " " + r.Path)
+	}
+	if r.Response == nil {
+		r.Response = &StubResponse{}
+	}
+	if r.Response.Code == 0 {
+		r.Response.Code = http.StatusOK
+	}
+	if r.Fault != nil {
+		if r.Fault.Rate == 0 {
+			r.Fault.Rate = 1
+		}
+		if r.Fault.Code == 0 {
+			r.Fault.Code = http.StatusInternalServerError
+		}
+	}
+}
+
+//Validate checks if route is valid
+func (r *Route) Validate() error {
+	if r.Name == "" {
+		return errors.New("route name was empty")
+	}
+	if r.Fault != nil && (r.Fault.Rate < 0 || r.Fault.Rate > 1) {
+		return fmt.Errorf("invalid route %v fault rate: %v, expected value from 0 to 1", r.Name, r.Fault.Rate)
+	}
+	return nil
+}
+
+//match returns path params and true if request matches route predicates
+func (r *Route) match(request *http.Request, requestData data.Map) (map[string]string, bool) {
+	if r.Method != "" && r.Method != request.Method {
+		return nil, false
+	}
+	params, ok := matchPath(r.Path, request.URL.Path)
+	if !ok {
+		return nil, false
+	}
+	for name, expected := range r.Header {
+		if values, has := request.Header[http.CanonicalHeaderKey(name)]; !has || !endpoint.MatchValue(expected, values[0]) {
+			return nil, false
+		}
+	}
+	query := request.URL.Query()
+	for name, expected := range r.Query {
+		if values, has := query[name]; !has || !endpoint.MatchValue(expected, values[0]) {
+			return nil, false
+		}
+	}
+	if len(r.Body) > 0 {
+		body, ok := requestData.GetValue("body")
+		if !ok || !toolbox.IsMap(body) {
+			return nil, false
+		}
+		bodyData := data.Map(toolbox.AsMap(body))
+		for path, expected := range r.Body {
+			if actual, has := bodyData.GetValue(path); !has || !endpoint.MatchValue(expected, actual) {
+				return nil, false
+			}
+		}
+	}
+	return params, true
+}
+
+//matchPath matches URL path with {name} params and trailing * pattern
+func matchPath(pattern, path string) (map[string]string, bool) {
+	var params = make(map[string]string)
+	if pattern == "" {
+		return params, true
+	}
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, patternSegment := range patternSegments {
+		if patternSegment == "*" && i == len(patternSegments)-1 {
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if strings.HasPrefix(patternSegment, "{") && strings.HasSuffix(patternSegment, "}") {
+			params[patternSegment[1:len(patternSegment)-1]] = segments[i]
+			continue
+		}
+		if patternSegment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, len(segments) == len(patternSegments)
+}
+
+//Routes represents stub routes, the first matching route handles a request
+type Routes []*Route
+
+//Init initialises routes
+func (r Routes) Init() error {
+	var names = make(map[string]bool)
+	for _, route := range r {
+		route.Init()
+		if err := route.Validate(); err != nil {
+			return err
+		}
+		if names[route.Name] {
+			return fmt.Errorf("duplicate route: %v", route.Name)
+		}
+		names[route.Name] = true
+	}
+	return nil
+}
+
+//Names returns route names
+func (r Routes) Names() []string {
+	var result = make([]string, len(r))
+	for i, route := range r {
+		result[i] = route.Name
+	}
+	return result
+}
+
+//match returns the first route matching the request with request data, or nil if no route matched
+func (r Routes) match(request *http.Request, body []byte) (*Route, data.Map) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	requestData := newStubRequestData(request, body)
+	for _, route := range r {
+		params, ok := route.match(request, requestData)
+		if !ok {
+			continue
+		}
+		var paramsData = data.NewMap()
+		for name, value := range params {
+			paramsData.Put(name, value)
+		}
+		requestData.Put("params", paramsData)
+		return route, requestData
+	}
+	return nil, nil
+}
+
+//respond writes route response expanded with matched request data
+func (r *Route) respond(writer http.ResponseWriter, requestData data.Map) error {
+	if r.LatencyMs > 0 {
+		time.Sleep(time.Duration(r.LatencyMs) * time.Millisecond)
+	}
+	if r.Fault != nil && rand.Float64() < r.Fault.Rate {
+		return r.Fault.inject(writer)
+	}
+	var state = data.NewMap()
+	state.Put(stubRequestKey, requestData)
+	for name, value := range r.Response.Header {
+		writer.Header().Set(name, state.ExpandAsText(value))
+	}
+	var body []byte
+	switch actual := r.Response.Body.(type) {
+	case nil:
+	case string:
+		body = []byte(state.ExpandAsText(actual))
+	default:
+		text, err := toolbox.AsJSONText(state.Expand(actual))
+		if err != nil {
+			return fmt.Errorf("failed to encode route %v response: %v", r.Name, err)
+		}
+		body = []byte(text)
+		if writer.Header().Get(ContentTypeKey) == "" {
+			writer.Header().Set(ContentTypeKey, "application/json")
+		}
+	}
+	writer.WriteHeader(r.Response.Code)
+	_, err := writer.Write(body)
+	return err
+}
+
+func (f *Fault) inject(writer http.ResponseWriter) error {
+	if f.Abort {
+		hijacker, ok := writer.(http.Hijacker)
+		if !ok {
+			return errors.New("unable to abort connection: hijacking not supported")
+		}
+		conn, _, err := hijacker.Hijack()
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	writer.WriteHeader(f.Code)
+	_, err := writer.Write([]byte(f.Body))
+	return err
+}
+
+//newStubRequestData returns request data used by route predicates and response templates
+func newStubRequestData(request *http.Request, body []byte) data.Map {
+	var result = data.NewMap()
+	result.Put("method", request.Method)
+	result.Put("URL", request.URL.String())
+	result.Put("path", request.URL.Path)
+	var query = data.NewMap()
+	for name, values := range request.URL.Query() {
+		query.Put(name, values[0])
+	}
+	result.Put("query", query)
+	var header = data.NewMap()
+	for name, values := range request.Header {
+		header.Put(name, values[0])
+	}
+	result.Put("header", header)
+	result.Put("body", string(body))
+	if len(body) > 0 {
+		if decoded, err := toolbox.JSONToInterface(string(body)); err == nil {
+			result.Put("body", decoded)
+		}
+	}
+	return result
+}
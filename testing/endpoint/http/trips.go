@@ -12,6 +12,7 @@ type HTTPServerTrips struct {
 	Rotate        bool
 	Trips         map[string]*HTTPResponses
 	IndexKeys     []string
+	Routes        Routes
 	Mutex         *sync.Mutex
 }
 
@@ -52,5 +53,8 @@ func (t *HTTPServerTrips) Init(requestTemplate string, respTemplate string) erro
 	if err != nil {
 		return fmt.Errorf("failed to load trips: %w", err)
 	}
+	if err = t.Routes.Init(); err != nil {
+		return fmt.Errorf("failed to init routes: %w", err)
+	}
 	return nil
 }
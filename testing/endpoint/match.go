@@ -0,0 +1,17 @@
+package endpoint
+
+import (
+	"github.com/viant/toolbox"
+	"regexp"
+	"strings"
+)
+
+//MatchValue compares text representation of values, expected value enclosed in /.../ is a regular expression
+func MatchValue(expected, actual interface{}) bool {
+	expectedText, actualText := toolbox.AsString(expected), toolbox.AsString(actual)
+	if len(expectedText) > 2 && strings.HasPrefix(expectedText, "/") && strings.HasSuffix(expectedText, "/") {
+		matched, err := regexp.MatchString(expectedText[1:len(expectedText)-1], actualText)
+		return err == nil && matched
+	}
+	return expectedText == actualText
+}
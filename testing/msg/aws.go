@@ -23,7 +23,7 @@ type awsClient struct {
 	timeout time.Duration
 }
 
-func (c *awsClient) sendMessage(dest *Resource, message *Message) (Result, error) {
+func (c *awsClient) sendMessage(ctx context.Context, dest *Resource, message *Message) (Result, error) {
 	queueURL, err := c.getQueueURL(dest.Name)
 	if err != nil {
 		return nil, err
@@ -38,14 +38,14 @@ func (c *awsClient) sendMessage(dest *Resource, message *Message) (Result, error
 	}
 	var body = toolbox.AsString(message.Data)
 	input.MessageBody = aws.String(body)
-	result, err := c.sqs.SendMessage(input)
+	result, err := c.sqs.SendMessageWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
 	return *result.MessageId, nil
 }
 
-func (c *awsClient) publishMessage(dest *Resource, message *Message) (Result, error) {
+func (c *awsClient) publishMessage(ctx context.Context, dest *Resource, message *Message) (Result, error) {
 	topicARN, err := c.getTopicARN(dest.Name)
 	if err != nil {
 		return nil, err
@@ -61,7 +61,7 @@ func (c *awsClient) publishMessage(dest *Resource, message *Message) (Result, er
 	var body = toolbox.AsString(message.Data)
 	input.Message = aws.String(body)
 	input.Subject = aws.String(message.Subject)
-	output, err := c.sns.Publish(input)
+	output, err := c.sns.PublishWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -71,9 +71,9 @@ func (c *awsClient) publishMessage(dest *Resource, message *Message) (Result, er
 func (c *awsClient) Push(ctx context.Context, dest *Resource, message *Message) (Result, error) {
 	switch dest.Type {
 	case ResourceTypeTopic:
-		return c.publishMessage(dest, message)
+		return c.publishMessage(ctx, dest, message)
 	case ResourceTypeQueue:
-		return c.sendMessage(dest, message)
+		return c.sendMessage(ctx, dest, message)
 
 	}
 	return nil, fmt.Errorf("unsupported resource type: %v", dest.Type)
@@ -89,7 +89,7 @@ func (c *awsClient) PullN(ctx context.Context, source *Resource, count int, nack
 	if waitTime > 20 {
 		waitTime = 20
 	}
-	if err := c.processMessages(queueURL, !nack, true, count, waitTime, func(msg *sqs.Message) (bool, error) {
+	if err := c.processMessages(ctx, queueURL, !nack, true, count, waitTime, func(msg *sqs.Message) (bool, error) {
 		message := buildMessage(msg)
 		result = append(result, message)
 		return len(result) < count, nil
@@ -148,7 +148,7 @@ func (c *awsClient) createSubscription(topicURL, queueURL string) (*Resource, er
 	return &Resource{URL: *output.SubscriptionArn}, nil
 }
 
-func (c *awsClient) processMessages(queueURL string, delete, includeAttributes bool, maxCount int, waitTimeSec int64, handler func(message *sqs.Message) (bool, error)) error {
+func (c *awsClient) processMessages(ctx context.Context, queueURL string, delete, includeAttributes bool, maxCount int, waitTimeSec int64, handler func(message *sqs.Message) (bool, error)) error {
 	count := maxCount
 	if count == 0 {
 		input := &sqs.GetQueueAttributesInput{
@@ -177,7 +177,7 @@ func (c *awsClient) processMessages(queueURL string, delete, includeAttributes b
 			pullCount = count % 10
 		}
 		receivedInput := buildReceiveMessageInput(queueURL, pullCount, waitTimeSec, includeAttributes)
-		output, err := c.sqs.ReceiveMessage(receivedInput)
+		output, err := c.sqs.ReceiveMessageWithContext(ctx, receivedInput)
 		if err != nil {
 			return errors.Wrapf(err, "failed to clean queue messages: %v", queueURL)
 		}
@@ -228,7 +228,7 @@ func (c *awsClient) createQueue(resource *ResourceSetup) (*Resource, error) {
 		}
 	} else if queueURL != "" {
 		//process and deletes outstanding messages
-		if err := c.processMessages(queueURL, true, false, 0, 20, nil); err != nil {
+		if err := c.processMessages(context.Background(), queueURL, true, false, 0, 20, nil); err != nil {
 			return nil, err
 		}
 	}
@@ -37,6 +37,7 @@ func NewPubSubClient(context *endly.Context, dest *Resource, timeout time.Durati
 	if err != nil {
 		return nil, err
 	}
+	context.Redactor().AddSecretFields(credConfig)
 	if len(dest.Brokers) > 0 {
 		dest.Vendor = ResourceVendorKafka
 		dest.Type = ResourceTypeTopic
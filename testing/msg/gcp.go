@@ -137,7 +137,7 @@ func (s *gcpClient) Push(ctx context.Context, dest *Resource, message *Message)
 	if err != nil {
 		return nil, err
 	}
-	ok, err := topic.Exists(s.ctx)
+	ok, err := topic.Exists(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -168,13 +168,14 @@ func (s *gcpClient) Push(ctx context.Context, dest *Resource, message *Message)
 		}
 	}
 
-	response := topic.Publish(s.ctx, pubMessage)
-	serverId, err := response.Get(s.ctx)
+	response := topic.Publish(ctx, pubMessage)
+	serverId, err := response.Get(ctx)
 	if err != nil {
 		return response, err
 	}
 	select {
 	case <-response.Ready():
+	case <-ctx.Done():
 	case <-time.After(s.timeout):
 		log.Printf("publish ready timeout reached: %s", s.timeout)
 	}
@@ -187,7 +188,7 @@ func (s *gcpClient) PullN(ctx context.Context, source *Resource, max int, nack b
 		return nil, err
 	}
 	var pulledCounter int32 = 0
-	ctx, cancel := context.WithCancel(s.ctx)
+	ctx, cancel := context.WithCancel(ctx)
 	go func() {
 		time.Sleep(s.timeout)
 
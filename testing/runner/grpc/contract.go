@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"errors"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/testing/validator"
+	"strings"
+)
+
+//CallRequest represents gRPC method call request
+type CallRequest struct {
+	*model.Repeater
+	Address    string            `required:"true" description:"gRPC server address, i.e. 127.0.0.1:8080"`
+	Method     string            `required:"true" description:"fully qualified method name, i.e. package.Service/Method"`
+	ProtoFiles []string          `description:"proto files defining the service, server reflection is used if empty"`
+	ImportPath string            `description:"proto import path, the first proto file directory by default"`
+	Request    interface{}       `description:"request message as JSON text or data"`
+	Metadata   map[string]string `description:"outgoing request metadata"`
+	TLS        bool              `description:"flag to use TLS transport with system root certificates"`
+	TimeoutMs  int               `description:"call timeout, 30000 by default"`
+	Expect     interface{}       `description:"If specified it will validated response as actual, server streaming response is a slice of messages"`
+}
+
+//Init initialises request
+func (r *CallRequest) Init() error {
+	if r.TimeoutMs == 0 {
+		r.TimeoutMs = 30000
+	}
+	return nil
+}
+
+//Validate checks if request is valid
+func (r *CallRequest) Validate() error {
+	if r.Address == "" {
+		return errors.New("address was empty")
+	}
+	if r.Method == "" {
+		return errors.New("method was empty")
+	}
+	return nil
+}
+
+//ServiceAndMethod returns fully qualified service name and method name
+func (r *CallRequest) ServiceAndMethod() (string, string, error) {
+	method := strings.TrimPrefix(r.Method, "/")
+	index := strings.LastIndex(method, "/")
+	if index == -1 {
+		index = strings.LastIndex(method, ".")
+	}
+	if index <= 0 || index == len(method)-1 {
+		return "", "", errors.New("invalid method: " + r.Method + ", expected package.Service/Method")
+	}
+	return method[:index], method[index+1:], nil
+}
+
+//CallResponse represents gRPC method call response
+type CallResponse struct {
+	Response interface{} `description:"response message, or slice of messages for server streaming method"`
+	Header   map[string][]string
+	Assert   *validator.AssertResponse
+}
@@ -0,0 +1,242 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"github.com/viant/endly"
+	"github.com/viant/endly/testing/validator"
+	"github.com/viant/endly/udf"
+	"github.com/viant/toolbox/url"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"io"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+//ServiceID represents gRPC runner service id.
+const ServiceID = "grpc/runner"
+
+type service struct {
+	*endly.AbstractService
+}
+
+func (s *service) call(context *endly.Context, request *CallRequest) (*CallResponse, error) {
+	var response = &CallResponse{}
+	repeater := request.Repeater.Init()
+	var extracted = make(map[string]interface{})
+	var state = context.State()
+	var message = request.Request
+	if message != nil {
+		message = state.Expand(message)
+	}
+	defer context.WithTimeout(time.Duration(request.TimeoutMs) * time.Millisecond)()
+	ctx := context.Background()
+	conn, err := dial(ctx, context.Expand(request.Address), request.TLS)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	method, err := s.lookupMethod(ctx, conn, context, request)
+	if err != nil {
+		return nil, err
+	}
+	if len(request.Metadata) > 0 {
+		var pairs = make(map[string]string)
+		for k, v := range request.Metadata {
+			pairs[k] = context.Expand(v)
+		}
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(pairs))
+	}
+	handler := func() (interface{}, error) {
+		result, header, err := invoke(ctx, conn, method, message)
+		if err != nil {
+			return nil, err
+		}
+		response.Response = result
+		response.Header = header
+		return result, nil
+	}
+	if err = repeater.Run(s.AbstractService, "GRPCRunner", context, handler, extracted); err != nil {
+		return response, err
+	}
+	if request.Expect != nil {
+		response.Assert, err = validator.Assert(context, request, request.Expect, response.Response, "GRPC.response", "assert gRPC response")
+	}
+	return response, err
+}
+
+func dial(ctx context.Context, address string, useTLS bool) (*grpc.ClientConn, error) {
+	var transportCredentials = insecure.NewCredentials()
+	if useTLS {
+		transportCredentials = credentials.NewTLS(&tls.Config{})
+	}
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(transportCredentials), grpc.WithBlock())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v, %v", address, err)
+	}
+	return conn, nil
+}
+
+//lookupMethod returns method descriptor from proto files or server reflection
+func (s *service) lookupMethod(ctx context.Context, conn *grpc.ClientConn, context *endly.Context, request *CallRequest) (*desc.MethodDescriptor, error) {
+	serviceName, methodName, err := request.ServiceAndMethod()
+	if err != nil {
+		return nil, err
+	}
+	var serviceDescriptor *desc.ServiceDescriptor
+	if len(request.ProtoFiles) == 0 {
+		client := grpcreflect.NewClient(ctx, rpb.NewServerReflectionClient(conn))
+		defer client.Reset()
+		if serviceDescriptor, err = client.ResolveService(serviceName); err != nil {
+			return nil, fmt.Errorf("failed to resolve service %v with server reflection, %v", serviceName, err)
+		}
+	} else {
+		if serviceDescriptor, err = lookupService(context, request, serviceName); err != nil {
+			return nil, err
+		}
+	}
+	method := serviceDescriptor.FindMethodByName(methodName)
+	if method == nil {
+		return nil, fmt.Errorf("failed to lookup method %v in service %v", methodName, serviceName)
+	}
+	if method.IsClientStreaming() {
+		return nil, fmt.Errorf("unsupported client streaming method: %v", request.Method)
+	}
+	return method, nil
+}
+
+func lookupService(context *endly.Context, request *CallRequest, serviceName string) (*desc.ServiceDescriptor, error) {
+	var files = make([]string, len(request.ProtoFiles))
+	for i, file := range request.ProtoFiles {
+		files[i] = url.NewResource(context.Expand(file)).ParsedURL.Path
+	}
+	importPath := path.Dir(files[0])
+	if request.ImportPath != "" {
+		importPath = url.NewResource(context.Expand(request.ImportPath)).ParsedURL.Path
+	}
+	for i, file := range files {
+		if relative, err := filepath.Rel(importPath, file); err == nil {
+			files[i] = relative
+		}
+	}
+	descriptors, err := udf.ParseProtoFiles(importPath, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto files: %v, %v", files, err)
+	}
+	for _, descriptor := range descriptors {
+		if serviceDescriptor := descriptor.FindService(serviceName); serviceDescriptor != nil {
+			return serviceDescriptor, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to lookup service %v in %v", serviceName, files)
+}
+
+//invoke calls unary or server streaming method, server streaming method returns slice of messages
+func invoke(ctx context.Context, conn *grpc.ClientConn, method *desc.MethodDescriptor, source interface{}) (interface{}, map[string][]string, error) {
+	if source == nil {
+		source = map[string]interface{}{}
+	}
+	message, err := udf.NewProtoMessage(method.GetInputType(), source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %v message, %v", method.GetInputType().GetFullyQualifiedName(), err)
+	}
+	stub := grpcdynamic.NewStub(conn)
+	var header metadata.MD
+	if !method.IsServerStreaming() {
+		output, err := stub.InvokeRpc(ctx, method, message, grpc.Header(&header))
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := asMap(output)
+		return result, header, err
+	}
+	stream, err := stub.InvokeRpcServerStream(ctx, method, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	var result = make([]interface{}, 0)
+	for {
+		output, err := stream.RecvMsg()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		item, err := asMap(output)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, item)
+	}
+	header, _ = stream.Header()
+	return result, header, nil
+}
+
+func asMap(message proto.Message) (map[string]interface{}, error) {
+	dynamicMessage, err := dynamic.AsDynamicMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	return udf.ProtoMessageAsMap(dynamicMessage)
+}
+
+const grpcCallExample = `{
+	"Address": "127.0.0.1:8080",
+	"Method": "greeter.Greeter/SayHello",
+	"ProtoFiles": ["proto/greeter.proto"],
+	"Request": {
+		"name": "Bob"
+	},
+	"Expect": {
+		"message": "Hello Bob"
+	}
+}`
+
+func (s *service) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "call",
+		RequestInfo: &endly.ActionInfo{
+			Description: "call unary or server streaming gRPC method",
+			Examples: []*endly.UseCase{
+				{
+					Description: "call method",
+					Data:        grpcCallExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &CallRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &CallResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*CallRequest); ok {
+				return s.call(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+//New creates a new gRPC runner service
+func New() endly.Service {
+	var result = &service{
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,163 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	runner "github.com/viant/endly/testing/runner/grpc"
+	"github.com/viant/endly/udf"
+	"github.com/viant/toolbox"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"net"
+	"path"
+	"testing"
+)
+
+//startGreeterServer starts dynamic greeter server with server reflection
+func startGreeterServer(t *testing.T, port int) (*grpc.Server, error) {
+	parent := toolbox.CallerDirectory(3)
+	descriptors, err := udf.ParseProtoFiles(path.Join(parent, "../../../test/grpc"), "greeter.proto")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = protoregistry.GlobalFiles.FindFileByPath("greeter.proto"); err != nil {
+		file, err := protodesc.NewFile(descriptors[0].AsFileDescriptorProto(), protoregistry.GlobalFiles)
+		if err != nil {
+			return nil, err
+		}
+		if err = protoregistry.GlobalFiles.RegisterFile(file); err != nil {
+			return nil, err
+		}
+	}
+	service := descriptors[0].FindService("greeter.Greeter")
+	sayHello := service.FindMethodByName("SayHello")
+	streamHello := service.FindMethodByName("StreamHello")
+	reply := func(method *desc.MethodDescriptor, request *dynamic.Message, index int) *dynamic.Message {
+		response := dynamic.NewMessage(method.GetOutputType())
+		response.SetFieldByName("message", fmt.Sprintf("Hello %v %v", request.GetFieldByName("name"), index))
+		return response
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: service.GetFullyQualifiedName(),
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "SayHello",
+				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+					request := dynamic.NewMessage(sayHello.GetInputType())
+					if err := dec(request); err != nil {
+						return nil, err
+					}
+					return reply(sayHello, request, 0), nil
+				},
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "StreamHello",
+				ServerStreams: true,
+				Handler: func(srv interface{}, stream grpc.ServerStream) error {
+					request := dynamic.NewMessage(streamHello.GetInputType())
+					if err := stream.RecvMsg(request); err != nil {
+						return err
+					}
+					count := toolbox.AsInt(request.GetFieldByName("count"))
+					for i := 0; i < count; i++ {
+						if err := stream.SendMsg(reply(streamHello, request, i)); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+		},
+		Metadata: "greeter.proto",
+	}, struct{}{})
+	reflection.Register(server)
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%v", port))
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return server, nil
+}
+
+func TestService_Call(t *testing.T) {
+	server, err := startGreeterServer(t, 8921)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer server.Stop()
+	parent := toolbox.CallerDirectory(3)
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := manager.Service(runner.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var useCases = []struct {
+		description string
+		request     *runner.CallRequest
+		expect      interface{}
+		hasError    bool
+	}{
+		{
+			description: "unary call with proto files",
+			request: &runner.CallRequest{
+				Method:     "greeter.Greeter/SayHello",
+				ProtoFiles: []string{path.Join(parent, "../../../test/grpc/greeter.proto")},
+				Request:    map[string]interface{}{"name": "Bob"},
+				Expect:     map[string]interface{}{"message": "Hello Bob 0"},
+			},
+			expect: map[string]interface{}{"message": "Hello Bob 0"},
+		},
+		{
+			description: "server streaming call with server reflection",
+			request: &runner.CallRequest{
+				Method:  "greeter.Greeter.StreamHello",
+				Request: `{"Name":"Ann","Count":2}`,
+			},
+			expect: []interface{}{
+				map[string]interface{}{"message": "Hello Ann 0"},
+				map[string]interface{}{"message": "Hello Ann 1"},
+			},
+		},
+		{
+			description: "unknown method",
+			request: &runner.CallRequest{
+				Method: "greeter.Greeter/Unknown",
+			},
+			hasError: true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		useCase.request.Address = "127.0.0.1:8921"
+		serviceResponse := service.Run(context, useCase.request)
+		if useCase.hasError {
+			assert.NotEqual(t, "", serviceResponse.Error, useCase.description)
+			continue
+		}
+		if !assert.Equal(t, "", serviceResponse.Error, useCase.description) {
+			continue
+		}
+		response, ok := serviceResponse.Response.(*runner.CallResponse)
+		if !assert.True(t, ok, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, response.Response, useCase.description)
+		if response.Assert != nil {
+			assert.Equal(t, 0, response.Assert.FailedCount, useCase.description)
+		}
+	}
+}
@@ -89,7 +89,7 @@ func (r *Request) Build(context *endly.Context, sessionCookies Cookies) (*http.R
 		reader = bytes.NewReader(body)
 	}
 
-	httpRequest, err := http.NewRequest(strings.ToUpper(request.Method), request.URL, reader)
+	httpRequest, err := http.NewRequestWithContext(context.Background(), strings.ToUpper(request.Method), request.URL, reader)
 	if err != nil {
 		return nil, expectBinary, err
 	}
@@ -25,11 +25,12 @@ func StartTestServer(port int, basedir string, rotate bool, indexBy ...string) e
 		indexBy = []string{endpoint.MethodKey, endpoint.URLKey, endpoint.BodyKey, endpoint.CookieKey, endpoint.ContentTypeKey}
 	}
 	baseDir := toolbox.CallerDirectory(3)
-	return endpoint.StartServer(port, &endpoint.HTTPServerTrips{
+	_, err := endpoint.StartServer(port, &endpoint.HTTPServerTrips{
 		IndexKeys:     indexBy,
 		Rotate:        rotate,
 		BaseDirectory: path.Join(baseDir, basedir),
-	})
+	}, "bridge.HttpRequest-%v.json", "bridge.HttpResponse-%v.json")
+	return err
 }
 
 func TestHttpRunnerService_Run(t *testing.T) {
@@ -248,8 +249,9 @@ func TestHttpRunnerService_PayloadTransformation(t *testing.T) {
 				assert.EqualValues(t, 2, len(sendResponse.Responses))
 				for _, response := range sendResponse.Responses {
 					assert.EqualValues(t, 200, response.Code)
-					assert.EqualValues(t, 1, response.JSONBody["Id"])
-					assert.EqualValues(t, "abc", response.JSONBody["Name"])
+					body := toolbox.AsMap(response.JSONBody)
+					assert.EqualValues(t, 1, body["Id"])
+					assert.EqualValues(t, "abc", body["Name"])
 				}
 			}
 		}
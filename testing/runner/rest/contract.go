@@ -11,13 +11,31 @@ type Request struct {
 	Options     map[string]interface{} `description:"http client options_: key value pairs, where key is one of the following: HTTP options_:RequestTimeoutMs,TimeoutMs,KeepAliveTimeMs,TLSHandshakeTimeoutMs,ResponseHeaderTimeoutMs,MaxIdleConns,FollowRedirects"`
 	httpOptions []*toolbox.HttpOptions
 	*model.Repeater
-	URL     string
-	Method  string
-	Request interface{}
-	Expect  interface{} `description:"If specified it will validated response as actual"`
+	URL           string
+	Method        string
+	Request       interface{}
+	Query         string                 `description:"GraphQL query or mutation, if specified request body is built from Query, Variables and OperationName"`
+	Variables     map[string]interface{} `description:"GraphQL variables"`
+	OperationName string                 `description:"GraphQL operation name"`
+	Expect        interface{}            `description:"If specified it will validated response as actual"`
+}
+
+//GraphQLRequest represents GraphQL request body
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+//IsGraphQL returns true if request represents GraphQL query or mutation
+func (r *Request) IsGraphQL() bool {
+	return r.Query != ""
 }
 
 func (r *Request) Init() error {
+	if r.IsGraphQL() && r.Method == "" {
+		r.Method = "POST"
+	}
 	r.httpOptions = make([]*toolbox.HttpOptions, 0)
 	if len(r.Options) > 0 {
 		for k, v := range r.Options {
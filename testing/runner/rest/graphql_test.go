@@ -0,0 +1,87 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	runner "github.com/viant/endly/testing/runner/rest"
+	"github.com/viant/toolbox"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRestRunnerService_GraphQL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var graphQLRequest = &runner.GraphQLRequest{}
+		if request.Method != "POST" || json.NewDecoder(request.Body).Decode(graphQLRequest) != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var response = map[string]interface{}{
+			"data": map[string]interface{}{
+				"user": map[string]interface{}{
+					"id":        graphQLRequest.Variables["id"],
+					"operation": graphQLRequest.OperationName,
+				},
+			},
+		}
+		if graphQLRequest.Variables["id"] == "0" {
+			response = map[string]interface{}{
+				"errors": []interface{}{map[string]interface{}{"message": "user not found"}},
+			}
+		}
+		_ = json.NewEncoder(writer).Encode(response)
+	}))
+	defer server.Close()
+
+	manager := endly.New()
+	service, err := manager.Service(runner.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	state := context.State()
+	state.Put("userID", "123")
+	query := "query User($id: ID!) { user(id: $id) { id } }"
+
+	{ //query with expanded variables and expect
+		serviceResponse := service.Run(context, &runner.Request{
+			URL:           server.URL,
+			Query:         query,
+			OperationName: "User",
+			Variables:     map[string]interface{}{"id": "${userID}"},
+			Expect: map[string]interface{}{
+				"data": map[string]interface{}{
+					"user": map[string]interface{}{"id": "123", "operation": "User"},
+				},
+			},
+		})
+		if assert.Equal(t, "", serviceResponse.Error) {
+			response, ok := serviceResponse.Response.(*runner.Response)
+			if assert.True(t, ok) {
+				assert.Equal(t, 2, response.Assert.PassedCount)
+				assert.Equal(t, 0, response.Assert.FailedCount)
+			}
+		}
+	}
+	{ //errors without expect
+		serviceResponse := service.Run(context, &runner.Request{
+			URL:       server.URL,
+			Query:     query,
+			Variables: map[string]interface{}{"id": "0"},
+		})
+		assert.Contains(t, serviceResponse.Error, "user not found")
+	}
+	{ //errors with expect
+		serviceResponse := service.Run(context, &runner.Request{
+			URL:       server.URL,
+			Query:     query,
+			Variables: map[string]interface{}{"id": "0"},
+			Expect: map[string]interface{}{
+				"errors": []interface{}{map[string]interface{}{"message": "user not found"}},
+			},
+		})
+		assert.Equal(t, "", serviceResponse.Error)
+	}
+}
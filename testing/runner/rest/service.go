@@ -5,6 +5,7 @@ import (
 	"github.com/viant/endly"
 	"github.com/viant/endly/testing/validator"
 	"github.com/viant/toolbox"
+	"strings"
 )
 
 //ServiceID represents rest service id.
@@ -26,6 +27,14 @@ func (s *restService) sendRequest(context *endly.Context, request *Request) (*Re
 	if req != nil {
 		req = state.Expand(req)
 	}
+	if request.IsGraphQL() {
+		//query is not expanded as GraphQL uses $ prefixed variables
+		var graphQLRequest = &GraphQLRequest{Query: request.Query, OperationName: request.OperationName}
+		if len(request.Variables) > 0 {
+			graphQLRequest.Variables = toolbox.AsMap(state.Expand(request.Variables))
+		}
+		req = graphQLRequest
+	}
 
 	handler := func() (interface{}, error) {
 		var JSONResponse = make(map[string]interface{})
@@ -42,10 +51,51 @@ func (s *restService) sendRequest(context *endly.Context, request *Request) (*Re
 	}
 	if request.Expect != nil {
 		response.Assert, err = validator.Assert(context, request, request.Expect, response.Response, "REST.response", "assert REST response")
+	} else if request.IsGraphQL() {
+		err = graphQLError(response.Response)
 	}
 	return response, err
 }
 
+//graphQLError returns an error if GraphQL response has errors
+func graphQLError(response interface{}) error {
+	JSONResponse, ok := response.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	errors, ok := JSONResponse["errors"].([]interface{})
+	if !ok || len(errors) == 0 {
+		return nil
+	}
+	var messages = make([]string, 0)
+	for _, item := range errors {
+		if toolbox.IsMap(item) {
+			if message, ok := toolbox.AsMap(item)["message"]; ok {
+				messages = append(messages, toolbox.AsString(message))
+				continue
+			}
+		}
+		messages = append(messages, toolbox.AsString(item))
+	}
+	return fmt.Errorf("graphQL errors: %v", strings.Join(messages, "; "))
+}
+
+const graphQLSendExample = `
+{
+		"URL": "http://127.0.0.1:8085/graphql",
+		"Query": "query User($id: ID!) { user(id: $id) { id name } }",
+		"Variables": {
+			"id": "${userID}"
+		},
+		"Expect": {
+			"data": {
+				"user": {
+					"name": "Bob"
+				}
+			}
+		}
+	}`
+
 const restSendExample = `
 {
 		"URL": "http://127.0.0.1:8085/v1/reporter/register/",
@@ -84,6 +134,10 @@ func (s *restService) registerRoutes() {
 					Description: "send request",
 					Data:        restSendExample,
 				},
+				{
+					Description: "send GraphQL query",
+					Data:        graphQLSendExample,
+				},
 			},
 		},
 		RequestProvider: func() interface{} {
@@ -12,10 +12,11 @@ import (
 
 func StartRestTestServer(port int) error {
 	baseDir := toolbox.CallerDirectory(3)
-	return endpoint.StartServer(port, &endpoint.HTTPServerTrips{
+	_, err := endpoint.StartServer(port, &endpoint.HTTPServerTrips{
 		IndexKeys:     []string{endpoint.MethodKey, endpoint.URLKey, endpoint.BodyKey, endpoint.CookieKey, endpoint.ContentTypeKey},
 		BaseDirectory: path.Join(baseDir, "test/send"),
-	})
+	}, "bridge.HttpRequest-%v.json", "bridge.HttpResponse-%v.json")
+	return err
 }
 
 func TestResetRunnerService_Run(t *testing.T) {
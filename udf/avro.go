@@ -38,7 +38,7 @@ func NewAvroReader(source interface{}, state data.Map) (interface{}, error) {
 	return toolbox.AsJSONText(datums)
 }
 
-func getAvroSchema(args interface{}) (string, error) {
+func getSchema(args interface{}) (string, error) {
 	textArg := strings.TrimSpace(toolbox.AsString(args))
 	if strings.HasPrefix(textArg, "{") {
 		return textArg, nil
@@ -53,7 +53,7 @@ func NewAvroWriter(args ...interface{}) (func(source interface{}, state data.Map
 	if len(args) == 0 {
 		return nil, fmt.Errorf("no sufficent args |usage: NewAvroWriter(avroSchema|URL, compression)")
 	}
-	schema, err := getAvroSchema(args[0])
+	schema, err := getSchema(args[0])
 	if err != nil {
 		return nil, err
 	}
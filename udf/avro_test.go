@@ -25,7 +25,9 @@ func TestNewAvroWriterProvider(t *testing.T) {
 	assert.NotNil(t, transformed)
 	output, err := NewAvroReader(transformed, nil)
 	assert.Nil(t, err)
-	assertly.AssertValues(t, "{\"Desc\":\"test\",\"ID\":1}", output)
+	decoded, err := toolbox.JSONToInterface(output)
+	assert.Nil(t, err)
+	assertly.AssertValues(t, "{\"Desc\":\"test\",\"ID\":1}", decoded)
 }
 
 func TestRegisterProviders(t *testing.T) {
@@ -0,0 +1,122 @@
+package udf
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"os"
+	"os/exec"
+	"plugin"
+	"strings"
+	"time"
+)
+
+const (
+	//Base64Encoding represents base64 encoded binary payload in external UDF protocol
+	Base64Encoding = "base64"
+	//ExecutableTimeoutEnvKey represents env variable with external executable UDF timeout in ms
+	ExecutableTimeoutEnvKey  = "ENDLY_UDF_TIMEOUT_MS"
+	defaultExecutableTimeout = time.Minute
+)
+
+//ExecutableRequest represents external executable UDF request, written as JSON to executable stdin
+type ExecutableRequest struct {
+	Source   interface{} `json:"source" description:"UDF source, binary source is base64 encoded"`
+	Encoding string      `json:"encoding,omitempty" description:"base64 if source was binary"`
+}
+
+//ExecutableResponse represents external executable UDF response, read as JSON from executable stdout
+type ExecutableResponse struct {
+	Result   interface{} `json:"result" description:"transformed source"`
+	Encoding string      `json:"encoding,omitempty" description:"base64 if result is base64 encoded binary"`
+	Error    string      `json:"error,omitempty" description:"transformation error"`
+}
+
+//NewExecutable creates UDF running external executable for each call, it takes executable path followed by its arguments, source is passed as ExecutableRequest JSON to stdin, ExecutableResponse JSON is expected on stdout
+func NewExecutable(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	if len(args) == 0 || toolbox.AsString(args[0]) == "" {
+		return nil, fmt.Errorf("failed to create executable udf - expected executable argument")
+	}
+	command := toolbox.AsString(args[0])
+	if _, err := exec.LookPath(command); err != nil {
+		return nil, fmt.Errorf("failed to lookup udf executable: %v, %v", command, err)
+	}
+	var arguments = make([]string, 0)
+	for _, arg := range args[1:] {
+		arguments = append(arguments, toolbox.AsString(arg))
+	}
+	return func(source interface{}, state data.Map) (interface{}, error) {
+		return runExecutable(command, arguments, source)
+	}, nil
+}
+
+func runExecutable(command string, arguments []string, source interface{}) (interface{}, error) {
+	var request = &ExecutableRequest{Source: source}
+	if binary, ok := source.([]byte); ok {
+		request.Source = base64.StdEncoding.EncodeToString(binary)
+		request.Encoding = Base64Encoding
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v udf request: %v", command, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), executableTimeout())
+	defer cancel()
+	cmd := exec.CommandContext(ctx, command, arguments...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run %v udf: %v, %v", command, err, strings.TrimSpace(stderr.String()))
+	}
+	var response = &ExecutableResponse{}
+	if err = json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("failed to decode %v udf response: %v, %s", command, err, stdout.Bytes())
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%v udf failed: %v", command, response.Error)
+	}
+	if response.Encoding == Base64Encoding {
+		return base64.StdEncoding.DecodeString(toolbox.AsString(response.Result))
+	}
+	return response.Result, nil
+}
+
+func executableTimeout() time.Duration {
+	if timeoutMs := toolbox.AsInt(os.Getenv(ExecutableTimeoutEnvKey)); timeoutMs > 0 {
+		return time.Duration(timeoutMs) * time.Millisecond
+	}
+	return defaultExecutableTimeout
+}
+
+//NewPlugin creates UDF from Go plugin, it takes plugin .so file and exported symbol name, symbol has to be a UDF or UDF provider, remaining arguments are passed to the provider
+func NewPlugin(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("failed to create plugin udf - expected plugin file and symbol arguments")
+	}
+	filename, name := toolbox.AsString(args[0]), toolbox.AsString(args[1])
+	loaded, err := plugin.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open udf plugin: %v, %v", filename, err)
+	}
+	symbol, err := loaded.Lookup(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup udf plugin %v symbol: %v, %v", filename, name, err)
+	}
+	switch actual := symbol.(type) {
+	case func(source interface{}, state data.Map) (interface{}, error):
+		return actual, nil
+	case *func(source interface{}, state data.Map) (interface{}, error):
+		return *actual, nil
+	case func(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error):
+		return actual(args[2:]...)
+	case *func(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error):
+		return (*actual)(args[2:]...)
+	}
+	return nil, fmt.Errorf("unsupported udf plugin %v symbol %v type: %T", filename, name, symbol)
+}
@@ -0,0 +1,78 @@
+package udf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox"
+	"path"
+	"testing"
+)
+
+func TestNewExecutable(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	executable := path.Join(parent, "test/external/upper.sh")
+
+	useCases := []struct {
+		description string
+		args        []interface{}
+		source      interface{}
+		expect      interface{}
+		hasError    bool
+	}{
+		{
+			description: "text source",
+			args:        []interface{}{executable},
+			source:      "abc",
+			expect:      "ABC",
+		},
+		{
+			description: "binary source",
+			args:        []interface{}{executable},
+			source:      []byte("abc"),
+			expect:      "YWJJ",
+		},
+		{
+			description: "executable arguments",
+			args:        []interface{}{executable, "x-"},
+			source:      "abc",
+			expect:      "x-ABC",
+		},
+		{
+			description: "udf error",
+			args:        []interface{}{executable},
+			source:      "fail",
+			hasError:    true,
+		},
+		{
+			description: "executable error",
+			args:        []interface{}{executable},
+			source:      "crash",
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		udf, err := NewExecutable(useCase.args...)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		actual, err := udf(useCase.source, nil)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+
+	_, err := NewExecutable(path.Join(parent, "test/external/missing.sh"))
+	assert.NotNil(t, err)
+}
+
+func TestNewPlugin(t *testing.T) {
+	_, err := NewPlugin("test/external/missing.so")
+	assert.NotNil(t, err)
+	_, err = NewPlugin("test/external/missing.so", "UDF")
+	assert.NotNil(t, err)
+}
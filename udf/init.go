@@ -21,5 +21,12 @@ func init() {
 	endly.UdfRegistryProvider["ProtoReader"] = NewProtoReader
 	endly.UdfRegistryProvider["ProtoWriter"] = NewProtoWriter
 	endly.UdfRegistryProvider["CsvReader"] = NewCsvReader
+	endly.UdfRegistryProvider["ParquetReader"] = NewParquetReader
+	endly.UdfRegistryProvider["ParquetWriter"] = NewParquetWriter
+	endly.UdfRegistryProvider["MsgPackReader"] = NewMsgPackReader
+	endly.UdfRegistryProvider["MsgPackWriter"] = NewMsgPackWriter
+	endly.UdfRegistryProvider["JSONSchemaValidator"] = NewJSONSchemaValidator
+	endly.UdfRegistryProvider["Executable"] = NewExecutable
+	endly.UdfRegistryProvider["Plugin"] = NewPlugin
 
 }
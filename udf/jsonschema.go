@@ -0,0 +1,65 @@
+package udf
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//JSONSchemaValidation represents JSON schema validation result
+type JSONSchemaValidation struct {
+	Valid      bool
+	Violations []*JSONSchemaViolation `json:",omitempty"`
+}
+
+//JSONSchemaViolation represents JSON schema violation
+type JSONSchemaViolation struct {
+	Field       string      `description:"violated field path, (root) for the whole payload"`
+	Type        string      `description:"violation type, i.e. required, invalid_type, enum"`
+	Description string      `description:"violation description"`
+	Value       interface{} `json:",omitempty"`
+}
+
+//NewJSONSchemaValidator creates a new JSON schema validator provider, it takes JSON schema or URL, created UDF returns JSONSchemaValidation as map
+func NewJSONSchemaValidator(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no sufficent args |usage: NewJSONSchemaValidator(jsonSchema|URL)")
+	}
+	schemaText, err := getSchema(args[0])
+	if err != nil {
+		return nil, err
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schemaText))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JSON schema: %v, %v", args[0], err)
+	}
+	return func(source interface{}, state data.Map) (interface{}, error) {
+		var input = source
+		switch source.(type) {
+		case []byte, string:
+			var err error
+			if input, err = toolbox.JSONToInterface(source); err != nil {
+				return nil, fmt.Errorf("failed to convert %v to JSON: %v ", source, err)
+			}
+		}
+		result, err := schema.Validate(gojsonschema.NewGoLoader(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate JSON schema: %v", err)
+		}
+		var validation = &JSONSchemaValidation{Valid: result.Valid()}
+		for _, resultError := range result.Errors() {
+			validation.Violations = append(validation.Violations, &JSONSchemaViolation{
+				Field:       resultError.Field(),
+				Type:        resultError.Type(),
+				Description: resultError.Description(),
+				Value:       resultError.Value(),
+			})
+		}
+		text, err := toolbox.AsJSONText(validation)
+		if err != nil {
+			return nil, err
+		}
+		return toolbox.JSONToMap(text)
+	}, nil
+}
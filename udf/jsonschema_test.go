@@ -0,0 +1,53 @@
+package udf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/toolbox"
+	"path"
+	"testing"
+)
+
+func TestNewJSONSchemaValidator(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	schemaFile := path.Join(parent, "test/jsonschema/user.json")
+
+	useCases := []struct {
+		description string
+		source      interface{}
+		expect      interface{}
+	}{
+		{
+			description: "valid payload",
+			source:      `{"id":1,"name":"Bob"}`,
+			expect:      `{"Valid":true}`,
+		},
+		{
+			description: "valid data",
+			source:      map[string]interface{}{"id": 1, "name": "Bob"},
+			expect:      `{"Valid":true}`,
+		},
+		{
+			description: "violations",
+			source:      `{"id":"1"}`,
+			expect: `{"Valid":false,"Violations":[
+	{"Field":"(root)","Type":"required"},
+	{"Field":"id","Type":"invalid_type","Value":"1"}
+]}`,
+		},
+	}
+
+	validator, err := NewJSONSchemaValidator(schemaFile)
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, useCase := range useCases {
+		actual, err := validator(useCase.source, nil)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assertly.AssertValues(t, useCase.expect, actual, useCase.description)
+	}
+	_, err = NewJSONSchemaValidator(`{"type": 1}`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,43 @@
+package udf
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+//NewMsgPackReader creates a new MessagePack reader provider, created UDF returns JSON text of decoded payload
+func NewMsgPackReader(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	return func(source interface{}, state data.Map) (interface{}, error) {
+		var payload []byte
+		switch data := source.(type) {
+		case []byte:
+			payload = data
+		case string:
+			payload = []byte(data)
+		default:
+			return nil, fmt.Errorf("unsupported input: %T, expected []byte or string", source)
+		}
+		var decoded interface{}
+		if err := msgpack.Unmarshal(payload, &decoded); err != nil {
+			return nil, fmt.Errorf("failed to decode msgpack: %v", err)
+		}
+		return toolbox.AsJSONText(decoded)
+	}, nil
+}
+
+//NewMsgPackWriter creates a new MessagePack writer provider, JSON text source is decoded before encoding
+func NewMsgPackWriter(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	return func(source interface{}, state data.Map) (interface{}, error) {
+		var input = source
+		switch source.(type) {
+		case []byte, string:
+			var err error
+			if input, err = toolbox.JSONToInterface(source); err != nil {
+				return nil, fmt.Errorf("failed to convert %v to JSON: %v ", source, err)
+			}
+		}
+		return msgpack.Marshal(input)
+	}, nil
+}
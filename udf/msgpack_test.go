@@ -0,0 +1,32 @@
+package udf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/toolbox"
+	"testing"
+)
+
+func TestNewMsgPackWriter(t *testing.T) {
+	writer, err := NewMsgPackWriter()
+	assert.Nil(t, err)
+	type user struct {
+		ID   int
+		Desc string
+	}
+	for _, source := range []interface{}{`{"ID":1,"Desc":"test"}`, &user{1, "test"}} {
+		transformed, err := writer(source, nil)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		reader, err := NewMsgPackReader()
+		assert.Nil(t, err)
+		output, err := reader(transformed, nil)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		actual, err := toolbox.JSONToInterface(output)
+		assert.Nil(t, err)
+		assertly.AssertValues(t, `{"Desc":"test","ID":1}`, actual)
+	}
+}
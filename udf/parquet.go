@@ -0,0 +1,175 @@
+package udf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+	"io"
+	"strings"
+)
+
+const parquetParallelism = 1
+
+//NewParquetReader creates a new parquet reader provider, created UDF returns JSON text of parquet records
+func NewParquetReader(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	return func(source interface{}, state data.Map) (interface{}, error) {
+		var payload []byte
+		switch data := source.(type) {
+		case []byte:
+			payload = data
+		case string:
+			payload = []byte(data)
+		default:
+			return nil, fmt.Errorf("unsupported input: %T, expected []byte or string", source)
+		}
+		parquetReader, err := reader.NewParquetReader(&parquetBuffer{data: payload}, nil, parquetParallelism)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create parquet reader: %v", err)
+		}
+		defer parquetReader.ReadStop()
+		records, err := parquetReader.ReadByNumber(int(parquetReader.GetNumRows()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read parquet records: %v", err)
+		}
+		var names = make(map[string]string)
+		for _, info := range parquetReader.SchemaHandler.Infos {
+			names[info.InName] = info.ExName
+		}
+		encoded, err := json.Marshal(records)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := toolbox.JSONToInterface(string(encoded))
+		if err != nil {
+			return nil, err
+		}
+		return toolbox.AsJSONText(renameParquetFields(decoded, names))
+	}, nil
+}
+
+//renameParquetFields replaces parquet go field names with schema field names
+func renameParquetFields(source interface{}, names map[string]string) interface{} {
+	switch actual := source.(type) {
+	case map[string]interface{}:
+		var result = make(map[string]interface{})
+		for key, value := range actual {
+			if name, ok := names[key]; ok {
+				key = name
+			}
+			result[key] = renameParquetFields(value, names)
+		}
+		return result
+	case []interface{}:
+		for i, item := range actual {
+			actual[i] = renameParquetFields(item, names)
+		}
+	}
+	return source
+}
+
+//NewParquetWriter creates a new parquet writer provider, it takes parquet JSON schema or URL, and optional compression codec
+func NewParquetWriter(args ...interface{}) (func(source interface{}, state data.Map) (interface{}, error), error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no sufficent args |usage: NewParquetWriter(parquetSchema|URL, compression)")
+	}
+	schema, err := getSchema(args[0])
+	if err != nil {
+		return nil, err
+	}
+	var compression = parquet.CompressionCodec_SNAPPY
+	if len(args) > 1 {
+		if compression, err = parquet.CompressionCodecFromString(strings.ToUpper(toolbox.AsString(args[1]))); err != nil {
+			return nil, err
+		}
+	}
+	return func(source interface{}, state data.Map) (interface{}, error) {
+		var input = source
+		var err error
+		switch source.(type) {
+		case []byte, string:
+			if input, err = toolbox.JSONToInterface(source); err != nil {
+				return nil, fmt.Errorf("failed to convert %v to JSON: %v ", source, err)
+			}
+		}
+		var records []interface{}
+		if toolbox.IsSlice(input) {
+			records = toolbox.AsSlice(input)
+		} else {
+			records = []interface{}{toolbox.AsMap(input)}
+		}
+		buffer := &parquetBuffer{}
+		parquetWriter, err := writer.NewJSONWriter(schema, buffer, parquetParallelism)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create parquet writer: %v", err)
+		}
+		parquetWriter.CompressionType = compression
+		for _, record := range records {
+			encoded, err := json.Marshal(record)
+			if err != nil {
+				return nil, err
+			}
+			if err = parquetWriter.Write(string(encoded)); err != nil {
+				return nil, fmt.Errorf("failed to write parquet record: %v", err)
+			}
+		}
+		if err = parquetWriter.WriteStop(); err != nil {
+			return nil, err
+		}
+		return buffer.data, nil
+	}, nil
+}
+
+//parquetBuffer represents in memory parquet file
+type parquetBuffer struct {
+	data   []byte
+	offset int64
+}
+
+func (b *parquetBuffer) Read(p []byte) (int, error) {
+	if b.offset >= int64(len(b.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, b.data[b.offset:])
+	b.offset += int64(n)
+	return n, nil
+}
+
+func (b *parquetBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	return len(p), nil
+}
+
+func (b *parquetBuffer) Seek(offset int64, whence int) (int64, error) {
+	var position int64
+	switch whence {
+	case io.SeekStart:
+		position = offset
+	case io.SeekCurrent:
+		position = b.offset + offset
+	case io.SeekEnd:
+		position = int64(len(b.data)) + offset
+	}
+	if position < 0 {
+		return 0, errors.New("negative position")
+	}
+	b.offset = position
+	return position, nil
+}
+
+func (b *parquetBuffer) Close() error {
+	return nil
+}
+
+func (b *parquetBuffer) Open(name string) (source.ParquetFile, error) {
+	return &parquetBuffer{data: b.data}, nil
+}
+
+func (b *parquetBuffer) Create(name string) (source.ParquetFile, error) {
+	return &parquetBuffer{}, nil
+}
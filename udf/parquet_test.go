@@ -0,0 +1,35 @@
+package udf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/toolbox"
+	"path"
+	"testing"
+)
+
+func TestNewParquetWriter(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	schemaFile := path.Join(parent, "test/parquet/user.json")
+
+	writer, err := NewParquetWriter(schemaFile, "gzip")
+	if !assert.Nil(t, err) {
+		return
+	}
+	transformed, err := writer(`[{"id":1,"name":"Bob"},{"id":2}]`, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, err := NewParquetReader()
+	assert.Nil(t, err)
+	output, err := reader(transformed, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	actual, err := toolbox.JSONToInterface(output)
+	assert.Nil(t, err)
+	assertly.AssertValues(t, `[{"id":1,"name":"Bob"},{"id":2,"name":null}]`, actual)
+
+	_, err = NewParquetWriter(schemaFile, "abc")
+	assert.NotNil(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/jhump/protoreflect/desc"
 	"github.com/jhump/protoreflect/desc/protoparse"
 	"github.com/jhump/protoreflect/dynamic"
 	"github.com/jhump/protoreflect/dynamic/msgregistry"
@@ -38,6 +39,11 @@ func (c *ProtoCodec) AsMessage(msgType string, data []byte) (interface{}, error)
 			return nil, fmt.Errorf("failed to unmarshal message: %v, due to %w", msgType, err)
 		}
 	}
+	return ProtoMessageAsMap(protoMsg)
+}
+
+//ProtoMessageAsMap converts dynamic proto message to map
+func ProtoMessageAsMap(protoMsg *dynamic.Message) (map[string]interface{}, error) {
 	JSON, err := protoMsg.MarshalJSON()
 	if err != nil {
 		return nil, err
@@ -52,6 +58,15 @@ func (c *ProtoCodec) AsBinary(msgType string, msg interface{}) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	protoMsg, err := NewProtoMessage(msgDescriptor, msg)
+	if err != nil {
+		return nil, err
+	}
+	return protoMsg.Marshal()
+}
+
+//NewProtoMessage creates dynamic proto message from JSON text or data, upper camel case fields are converted to lower camel case if needed
+func NewProtoMessage(msgDescriptor *desc.MessageDescriptor, msg interface{}) (*dynamic.Message, error) {
 	var reader io.Reader
 	switch value := msg.(type) {
 	case string:
@@ -73,7 +88,7 @@ func (c *ProtoCodec) AsBinary(msgType string, msg interface{}) ([]byte, error) {
 	protoMsg := dynamic.NewMessage(msgDescriptor)
 	err = protoMsg.UnmarshalJSON(data)
 	if err != nil {
-		data, err = c.toLowerCamel(err, data)
+		data, err = toLowerCamel(data)
 		if err != nil {
 			err = errors.Wrapf(err, "failed to convert to lowerCase fields")
 			return nil, err
@@ -84,12 +99,12 @@ func (c *ProtoCodec) AsBinary(msgType string, msg interface{}) ([]byte, error) {
 			return nil, err
 		}
 	}
-	return protoMsg.Marshal()
+	return protoMsg, nil
 }
 
-func (c *ProtoCodec) toLowerCamel(err error, data []byte) ([]byte, error) {
+func toLowerCamel(data []byte) ([]byte, error) {
 	aMap := map[string]interface{}{}
-	err = json.Unmarshal(data, &aMap)
+	err := json.Unmarshal(data, &aMap)
 	if err != nil {
 		return nil, errors.Wrapf(err, "invalid JSON")
 	}
@@ -108,8 +123,7 @@ func (c *ProtoCodec) toLowerCamel(err error, data []byte) ([]byte, error) {
 
 //NewProtoCodec creates a new protobuf codec
 func NewProtoCodec(schemaFile, importPath string, msgType string, lowercaseKey bool) (*ProtoCodec, error) {
-	parser := protoparse.Parser{ImportPaths: []string{importPath}, IncludeSourceCodeInfo: true}
-	descriptors, err := parser.ParseFiles(schemaFile)
+	descriptors, err := ParseProtoFiles(importPath, schemaFile)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +139,12 @@ func NewProtoCodec(schemaFile, importPath string, msgType string, lowercaseKey b
 
 }
 
+//ParseProtoFiles parses proto schema files relative to import path
+func ParseProtoFiles(importPath string, schemaFiles ...string) ([]*desc.FileDescriptor, error) {
+	parser := protoparse.Parser{ImportPaths: []string{importPath}, IncludeSourceCodeInfo: true}
+	return parser.ParseFiles(schemaFiles...)
+}
+
 func getProtoCodec(source string, args []interface{}) (*ProtoCodec, error) {
 	if len(args) < 2 {
 		return nil, fmt.Errorf("no sufficent args |usage: %v(schemaFile, messageType, importPath)", source)
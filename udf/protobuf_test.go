@@ -42,7 +42,7 @@ func TestProtoCodec_AsBinary(t *testing.T) {
 
 	for _, useCase := range useCases {
 
-		codec, err := NewProtoCodec(useCase.protoFile, useCase.importPath, useCase.messageType)
+		codec, err := NewProtoCodec(useCase.protoFile, useCase.importPath, useCase.messageType, false)
 		if !assert.Nil(t, err, useCase.description) {
 			log.Fatal(err)
 		}
@@ -46,6 +46,9 @@ func (s *service) register(context *endly.Context, request *RegisterRequest) (in
 	if err := RegisterProviders(request.UDFs); err != nil {
 		return nil, err
 	}
+	for _, udf := range request.UDFs {
+		state.Put(udf.ID, endly.UdfRegistry[udf.ID])
+	}
 	return &RegisterResponse{}, nil
 }
 
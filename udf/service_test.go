@@ -0,0 +1,32 @@
+package udf
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"path"
+	"testing"
+)
+
+func TestService_Register(t *testing.T) {
+	parent := toolbox.CallerDirectory(3)
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := context.Service(ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	response := service.Run(context, &RegisterRequest{
+		UDFs: []*endly.UdfProvider{
+			{
+				ID:       "UpperInContext",
+				Provider: "Executable",
+				Params:   []interface{}{path.Join(parent, "test/external/upper.sh")},
+			},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	assert.Equal(t, "ABC", context.Expand("$UpperInContext(abc)"))
+}
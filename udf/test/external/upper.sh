@@ -0,0 +1,9 @@
+#!/bin/sh
+# reads {"source":"..."} and writes upper cased source as result
+payload=$(cat)
+case "$payload" in
+  *fail*) echo '{"error":"unsupported source"}'; exit 0;;
+  *crash*) echo 'crashed' >&2; exit 3;;
+esac
+source=$(echo "$payload" | sed 's/.*"source":"\([^"]*\)".*/\1/')
+echo "{\"result\":\"$1$(echo "$source" | tr '[:lower:]' '[:upper:]')\"}"
@@ -101,7 +101,7 @@ func LoadData(source interface{}, state data.Map) (interface{}, error) {
 		return c, nil
 	}
 
-	return nil, fmt.Errorf("udf LoadData arguments must be string: v%", source)
+	return nil, fmt.Errorf("udf LoadData arguments must be string: %v", source)
 }
 
 //URLPath return path from URL
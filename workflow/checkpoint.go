@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/afs"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"time"
+)
+
+var checkpointFs = afs.New()
+
+//encodedFunc represents encodable map placeholder for func values
+const encodedFunc = "func()"
+
+//checkpointSkipKeys represents state keys that are either runtime only or managed by workflow service
+var checkpointSkipKeys = []string{selfStateKey, tasksStateKey}
+
+//LoadCheckpoint loads checkpoint from supplied URL, it returns nil if checkpoint does not exist
+func LoadCheckpoint(URL string) (*model.Checkpoint, error) {
+	ctx := context.Background()
+	if exists, _ := checkpointFs.Exists(ctx, URL); !exists {
+		return nil, nil
+	}
+	content, err := checkpointFs.DownloadWithURL(ctx, URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %v, %v", URL, err)
+	}
+	checkpoint := &model.Checkpoint{}
+	if err = json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %v, %v", URL, err)
+	}
+	return checkpoint, nil
+}
+
+//SaveCheckpoint persists process checkpoint with current context state
+func SaveCheckpoint(URL string, context *endly.Context, process *model.Process) error {
+	checkpoint := process.Checkpoint
+	if checkpoint == nil {
+		return nil
+	}
+	var state = context.State()
+	state = state.Clone()
+	for _, key := range checkpointSkipKeys {
+		state.Delete(key)
+	}
+	state.Delete(context.SessionID)
+	for key, value := range state {
+		if toolbox.IsFunc(value) {
+			delete(state, key)
+		}
+	}
+	checkpoint.State = state.AsEncodableMap()
+	if process.State != nil {
+		checkpoint.Process = process.State.AsEncodableMap()
+	}
+	checkpoint.Updated = time.Now()
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %v", err)
+	}
+	if err = checkpointFs.Upload(context.Background(), URL, 0644, bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %v, %v", URL, err)
+	}
+	return nil
+}
+
+//RemoveCheckpoint removes checkpoint once workflow has completed
+func RemoveCheckpoint(URL string) error {
+	ctx := context.Background()
+	if exists, _ := checkpointFs.Exists(ctx, URL); !exists {
+		return nil
+	}
+	return checkpointFs.Delete(ctx, URL)
+}
+
+//restoreCheckpoint applies checkpoint state to the context and process state
+func restoreCheckpoint(context *endly.Context, process *model.Process, checkpoint *model.Checkpoint, stateKey string) {
+	state := context.State()
+	for key, value := range checkpoint.State {
+		if key == stateKey || value == encodedFunc {
+			continue
+		}
+		if existing, ok := state[key]; ok && toolbox.IsFunc(existing) {
+			continue
+		}
+		state.Put(key, value)
+	}
+	if process.State == nil {
+		process.State = data.NewMap()
+	}
+	for key, value := range checkpoint.Process {
+		process.State.Put(key, value)
+	}
+	process.Checkpoint = checkpoint
+}
@@ -32,6 +32,12 @@ type RunRequest struct {
 	TagIDs            string `description:"coma separated TagID list, if present in a task, only matched runs, other task runWorkflow as normal"`
 	Tasks             string `required:"true" description:"coma separated task list, if empty or '*' runs all tasks sequentially"` //tasks to runWorkflow with coma separated list or '*', or empty string for all tasks
 	Interactive       bool
+	CheckpointURL     string                   `description:"optional checkpoint URL, if specified process state, completed tasks and context state are persisted after each task"`
+	Resume            bool                     `description:"flag to resume workflow from the first incomplete task recorded in CheckpointURL"`
+	Matrix            map[string][]interface{} `description:"parameter lists, workflow runs in isolated context for each combination (matrix cell)"`
+	MatrixParallelism int                      `description:"max number of matrix cells running concurrently, cells run sequentially if less than 2"`
+	DryRun            bool                     `description:"flag to walk tasks and actions, expand and validate service requests without calling any service"`
+	TraceURL          string                   `description:"optional OpenTelemetry trace export URL, http(s)://host:port[/path] for OTLP/HTTP, file path for JSON lines file; if empty ENDLY_TRACE_URL or OTEL_EXPORTER_OTLP_ENDPOINT env is used"`
 	*model.InlineWorkflow
 	workflow *model.Workflow //inline workflow from pipeline
 }
@@ -91,6 +97,20 @@ func (r *RunRequest) Init() (err error) {
 
 //Validate checks if request is valid
 func (r *RunRequest) Validate() error {
+	if r.Resume && r.CheckpointURL == "" {
+		return errors.New("checkpointURL was empty")
+	}
+	if len(r.Matrix) > 0 && r.CheckpointURL != "" {
+		return errors.New("checkpointURL is not supported with matrix")
+	}
+	for key, values := range r.Matrix {
+		if key == "" {
+			return errors.New("matrix parameter name was empty")
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("matrix.%v was empty", key)
+		}
+	}
 	if r.workflow != nil {
 		return r.workflow.Validate()
 	}
@@ -127,6 +147,27 @@ func NewRunRequestFromURL(URL string) (*RunRequest, error) {
 type RunResponse struct {
 	Data      map[string]interface{} //  data populated by  .Post variable section.
 	SessionID string                 //session id
+	Matrix    []*MatrixCellResponse  //matrix cells responses
+}
+
+//LintRequest represents workflow static validation request
+type LintRequest struct {
+	*RunRequest
+}
+
+//Init initialises request
+func (r *LintRequest) Init() error {
+	if r.RunRequest == nil {
+		r.RunRequest = &RunRequest{}
+	}
+	return r.RunRequest.Init()
+}
+
+//LintResponse represents workflow static validation response
+type LintResponse struct {
+	Diagnostics []*Diagnostic
+	Errors      int `description:"number of error diagnostics"`
+	Warnings    int `description:"number of warning diagnostics"`
 }
 
 //RegisterRequest represents workflow register request
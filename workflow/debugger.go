@@ -0,0 +1,197 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"strings"
+	"sync"
+)
+
+const (
+	//DebugContinue resumes execution until next breakpoint
+	DebugContinue = "continue"
+	//DebugStepOver pauses before next action, skipping actions of nested workflows
+	DebugStepOver = "next"
+	//DebugStepInto pauses before next action, including actions of nested workflows
+	DebugStepInto = "step"
+	//DebugStepOut pauses before next action of a caller workflow
+	DebugStepOut = "out"
+	//DebugAbort terminates workflow with an error
+	DebugAbort = "abort"
+)
+
+var debuggerKey = (*Debugger)(nil)
+
+//Breakpoint represents debugger breakpoint, empty attribute matches any value
+type Breakpoint struct {
+	Task   string `description:"task name"`
+	Action string `description:"action name, action or service:action"`
+	TagID  string `description:"action tag id"`
+}
+
+//Match returns true if breakpoint matches paused action
+func (b *Breakpoint) Match(pause *DebugPause) bool {
+	if b.TagID != "" && b.TagID != pause.TagID {
+		return false
+	}
+	if b.Task != "" && b.Task != "*" && b.Task != pause.Task {
+		return false
+	}
+	if b.Action != "" && b.Action != pause.Name && b.Action != pause.Action && b.Action != pause.Service+":"+pause.Action {
+		return false
+	}
+	return true
+}
+
+//String returns breakpoint expression
+func (b *Breakpoint) String() string {
+	if b.TagID != "" {
+		return "#" + b.TagID
+	}
+	if b.Action != "" {
+		return b.Task + "." + b.Action
+	}
+	return b.Task
+}
+
+//NewBreakpoint creates a breakpoint for supplied expression: task, task.action, *.action or #tagID
+func NewBreakpoint(expression string) *Breakpoint {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "#") {
+		return &Breakpoint{TagID: string(expression[1:])}
+	}
+	if index := strings.Index(expression, "."); index != -1 {
+		return &Breakpoint{Task: string(expression[:index]), Action: string(expression[index+1:])}
+	}
+	return &Breakpoint{Task: expression}
+}
+
+//DebugPause represents action paused by debugger
+type DebugPause struct {
+	Workflow   string
+	Task       string
+	TagID      string
+	Name       string
+	Service    string
+	Action     string
+	Request    interface{} `description:"request expanded with current state"`
+	Depth      int         `description:"workflow nesting level"`
+	Breakpoint string      `description:"matched breakpoint, empty when stepping"`
+}
+
+//NewDebugPause creates a debug pause for supplied activity
+func NewDebugPause(context *endly.Context, process *model.Process, action *model.Action, activity *model.Activity) *DebugPause {
+	var state = context.State()
+	var result = &DebugPause{
+		Name:    action.Name,
+		TagID:   activity.TagID,
+		Service: activity.Service,
+		Action:  activity.Action,
+		Request: state.Expand(activity.Request),
+		Depth:   processes(context).Len(),
+	}
+	if process.Workflow != nil {
+		result.Workflow = process.Workflow.Name
+	}
+	if process.Task != nil {
+		result.Task = process.Task.Name
+	}
+	return result
+}
+
+//DebugHandler handles paused action, it can inspect and modify context state, returned command controls how execution resumes
+type DebugHandler func(context *endly.Context, pause *DebugPause) string
+
+//Debugger represents workflow step debugger, it pauses before running action matching a breakpoint or while stepping
+type Debugger struct {
+	Breakpoints []*Breakpoint
+	Handler     DebugHandler
+	command     string
+	depth       int
+	mux         sync.Mutex
+}
+
+//shouldPause returns true if debugger should pause before supplied action
+func (d *Debugger) shouldPause(pause *DebugPause) bool {
+	switch d.command {
+	case DebugStepInto:
+		return true
+	case DebugStepOver:
+		if pause.Depth <= d.depth {
+			return true
+		}
+	case DebugStepOut:
+		if pause.Depth < d.depth {
+			return true
+		}
+	}
+	for _, breakpoint := range d.Breakpoints {
+		if breakpoint.Match(pause) {
+			pause.Breakpoint = breakpoint.String()
+			return true
+		}
+	}
+	return false
+}
+
+//Pause calls debug handler if supplied action should be paused, concurrent actions are paused one at a time, breakpoints can be modified by the handler
+func (d *Debugger) Pause(context *endly.Context, pause *DebugPause) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	if d.command == DebugAbort {
+		return errors.New("workflow was aborted by debugger")
+	}
+	if !d.shouldPause(pause) {
+		return nil
+	}
+	command := d.Handler(context, pause)
+	switch command {
+	case DebugContinue, DebugStepOver, DebugStepInto, DebugStepOut:
+	case DebugAbort:
+		d.command = command
+		return fmt.Errorf("workflow was aborted by debugger at %v", pause.TagID)
+	default:
+		return fmt.Errorf("unsupported debugger command: %v", command)
+	}
+	d.command = command
+	d.depth = pause.Depth
+	return nil
+}
+
+//NewDebugger creates a debugger, with no breakpoints it pauses before the first action
+func NewDebugger(handler DebugHandler, breakpoints ...*Breakpoint) *Debugger {
+	var result = &Debugger{
+		Handler:     handler,
+		Breakpoints: breakpoints,
+		command:     DebugContinue,
+	}
+	if len(breakpoints) == 0 {
+		result.command = DebugStepInto
+	}
+	return result
+}
+
+//SetDebugger attaches debugger to the context, it is inherited by nested workflows
+func SetDebugger(context *endly.Context, debugger *Debugger) {
+	_ = context.Replace(debuggerKey, debugger)
+}
+
+func getDebugger(context *endly.Context) *Debugger {
+	if !context.Contains(debuggerKey) {
+		return nil
+	}
+	var result *Debugger
+	context.GetInto(debuggerKey, &result)
+	return result
+}
+
+//debug pauses before running action if context has debugger attached
+func (s *Service) debug(context *endly.Context, process *model.Process, action *model.Action, activity *model.Activity) error {
+	debugger := getDebugger(context)
+	if debugger == nil || debugger.Handler == nil {
+		return nil
+	}
+	return debugger.Pause(context, NewDebugPause(context, process, action, activity))
+}
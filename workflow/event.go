@@ -1,8 +1,12 @@
 package workflow
 
 import (
+	"fmt"
 	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
 	"github.com/viant/toolbox/data"
+	"strings"
+	"time"
 )
 
 //LoadedEvent represents workflow load event
@@ -50,3 +54,107 @@ type AsyncEvent struct {
 func NewAsyncEvent(action *model.Action) *AsyncEvent {
 	return &AsyncEvent{action}
 }
+
+//ResumeEvent represents workflow resume from checkpoint event
+type ResumeEvent struct {
+	URL   string
+	Tasks []string
+}
+
+//NewResumeEvent creates a new ResumeEvent
+func NewResumeEvent(checkpoint *model.Checkpoint) *ResumeEvent {
+	return &ResumeEvent{
+		URL:   checkpoint.URL,
+		Tasks: checkpoint.Tasks,
+	}
+}
+
+//Messages returns tag messages
+func (e *ResumeEvent) Messages() []*msg.Message {
+	var header = fmt.Sprintf("%v, completed: %v", e.URL, strings.Join(e.Tasks, ","))
+	return []*msg.Message{msg.NewMessage(msg.NewStyled(header, msg.MessageStyleGeneric),
+		msg.NewStyled("resume", msg.MessageStyleGeneric))}
+}
+
+//AttemptEvent represents action attempt event, published for actions with retry policy
+type AttemptEvent struct {
+	TagID       string
+	Attempt     int
+	MaxAttempts int
+	Error       string
+	DelayMs     int
+}
+
+//NewAttemptEvent creates a new AttemptEvent
+func NewAttemptEvent(action *model.Action, attempt int, err error, delay time.Duration) *AttemptEvent {
+	var result = &AttemptEvent{
+		TagID:       action.TagID,
+		Attempt:     attempt,
+		MaxAttempts: action.Retry.MaxAttempts,
+		DelayMs:     int(delay / time.Millisecond),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+//Messages returns tag messages
+func (e *AttemptEvent) Messages() []*msg.Message {
+	var header = fmt.Sprintf("%v attempt %v/%v", e.TagID, e.Attempt, e.MaxAttempts)
+	var style = msg.MessageStyleSuccess
+	if e.Error != "" {
+		style = msg.MessageStyleError
+		header += ": " + e.Error
+		if e.DelayMs > 0 {
+			header += fmt.Sprintf(", retrying in %v ms", e.DelayMs)
+		}
+	}
+	return []*msg.Message{msg.NewMessage(msg.NewStyled(header, style),
+		msg.NewStyled("retry", style))}
+}
+
+//MatrixCellEvent represents matrix cell run start event, with concurrent cells it is also republished before events of a cell that was not the last to publish
+type MatrixCellEvent struct {
+	ID     string
+	Params map[string]interface{}
+}
+
+//NewMatrixCellEvent creates a new MatrixCellEvent
+func NewMatrixCellEvent(cell *MatrixCell) *MatrixCellEvent {
+	return &MatrixCellEvent{
+		ID:     cell.ID,
+		Params: cell.Params,
+	}
+}
+
+//Messages returns tag messages
+func (e *MatrixCellEvent) Messages() []*msg.Message {
+	return []*msg.Message{msg.NewMessage(msg.NewStyled(e.ID, msg.MessageStyleGeneric),
+		msg.NewStyled("matrix", msg.MessageStyleGroup))}
+}
+
+//MatrixCellEndEvent represents matrix cell run end event
+type MatrixCellEndEvent struct {
+	ID    string
+	Error string
+}
+
+//NewMatrixCellEndEvent creates a new MatrixCellEndEvent
+func NewMatrixCellEndEvent(response *MatrixCellResponse) *MatrixCellEndEvent {
+	return &MatrixCellEndEvent{
+		ID:    response.ID,
+		Error: response.Error,
+	}
+}
+
+//Messages returns tag messages
+func (e *MatrixCellEndEvent) Messages() []*msg.Message {
+	var header, style = e.ID, msg.MessageStyleSuccess
+	if e.Error != "" {
+		header += ": " + e.Error
+		style = msg.MessageStyleError
+	}
+	return []*msg.Message{msg.NewMessage(msg.NewStyled(header, style),
+		msg.NewStyled("matrix", style))}
+}
@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"reflect"
+	"sync"
+)
+
+const (
+	taskPending = iota
+	taskRunning
+	taskCompleted
+	taskFailed
+)
+
+//taskGraph represents tasks dependency graph
+type taskGraph struct {
+	tasks  []*model.Task
+	status map[string]int
+}
+
+//ready returns pending tasks with all dependencies completed, dependencies outside of the graph are ignored
+func (g *taskGraph) ready() []*model.Task {
+	var result = make([]*model.Task, 0)
+	for _, task := range g.tasks {
+		if g.status[task.Name] != taskPending {
+			continue
+		}
+		isReady := true
+		for _, dependency := range task.DependsOn {
+			if status, ok := g.status[dependency]; ok && status != taskCompleted {
+				isReady = false
+				break
+			}
+		}
+		if isReady {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+//blocked returns pending task names that can not run due to failed dependencies
+func (g *taskGraph) blocked() []string {
+	var result = make([]string, 0)
+	for _, task := range g.tasks {
+		if g.status[task.Name] == taskPending {
+			result = append(result, task.Name)
+		}
+	}
+	return result
+}
+
+func newTaskGraph(tasks *model.TasksNode, checkpoint *model.Checkpoint) *taskGraph {
+	var result = &taskGraph{
+		tasks:  make([]*model.Task, 0),
+		status: make(map[string]int),
+	}
+	for _, task := range tasks.Tasks {
+		if task.Name == tasks.OnErrorTask || task.Name == tasks.DeferredTask {
+			continue
+		}
+		result.tasks = append(result.tasks, task)
+		result.status[task.Name] = taskPending
+		if checkpoint.IsCompleted(task.Name) {
+			result.status[task.Name] = taskCompleted
+		}
+	}
+	return result
+}
+
+//taskRun represents concurrent task execution result
+type taskRun struct {
+	task    *model.Task
+	process *model.Process
+	context *endly.Context
+	err     error
+}
+
+//serializedListener returns listener forwarding events one at a time, so that concurrent tasks publish progress as it happens
+func serializedListener(listener msg.Listener) msg.Listener {
+	if listener == nil {
+		return nil
+	}
+	var mux = &sync.Mutex{}
+	return func(event msg.Event) {
+		mux.Lock()
+		defer mux.Unlock()
+		listener(event)
+	}
+}
+
+func (s *Service) runGraphTask(context *endly.Context, process *model.Process, task *model.Task) *taskRun {
+	var result = &taskRun{
+		task:    task,
+		context: context,
+		process: process.Fork(),
+	}
+	listener := context.Listener
+	context.MakeAsyncSafe()
+	context.SetListener(listener) //keep publishing through the serialized graph listener instead of buffered events
+	_ = context.Replace(processesKey, model.NewProcesses())
+	Push(context, result.process)
+	_, result.err = s.runTask(context, result.process, task)
+	return result
+}
+
+//mergeTaskState publishes task context state changes to the parent context state
+func (s *Service) mergeTaskState(context *endly.Context, run *taskRun) {
+	var state = context.State()
+	for key, value := range run.context.State() {
+		if toolbox.IsFunc(value) {
+			continue
+		}
+		if existing, ok := state[key]; ok && reflect.DeepEqual(existing, value) {
+			continue
+		}
+		state[key] = value
+	}
+}
+
+//runTasksGraph runs tasks concurrently honouring task dependencies and MaxParallelism
+func (s *Service) runTasksGraph(context *endly.Context, process *model.Process, tasks *model.TasksNode) (err error) {
+	graph := newTaskGraph(tasks, process.Checkpoint)
+	maxParallelism := tasks.MaxParallelism
+	if maxParallelism <= 0 {
+		maxParallelism = len(graph.tasks)
+	}
+	var listener = context.Listener
+	defer context.SetListener(listener)
+	context.SetListener(serializedListener(listener))
+	var done = make(chan *taskRun, len(graph.tasks))
+	var running = 0
+	var failed *taskRun
+	for {
+		if err == nil && process.CanRun() {
+			for _, task := range graph.ready() {
+				if running >= maxParallelism {
+					break
+				}
+				graph.status[task.Name] = taskRunning
+				running++
+				go func(task *model.Task, taskContext *endly.Context) {
+					done <- s.runGraphTask(taskContext, process, task)
+				}(task, context.Clone())
+			}
+		}
+		if running == 0 {
+			break
+		}
+		run := <-done
+		running--
+		if run.process.IsTerminated() {
+			process.Terminate()
+		}
+		if run.process.Scheduled != nil && process.Scheduled == nil {
+			process.Scheduled = run.process.Scheduled
+		}
+		taskErr := run.err
+		if taskErr == nil {
+			s.mergeTaskState(context, run)
+			taskErr = s.saveCheckpoint(context, process, run.task)
+		} else if failed == nil {
+			failed = run
+		}
+		if taskErr != nil {
+			graph.status[run.task.Name] = taskFailed
+			if err == nil {
+				err = taskErr
+			}
+			continue
+		}
+		graph.status[run.task.Name] = taskCompleted
+	}
+	if failed != nil {
+		//on error task runs once for the first failed task, after running tasks completed
+		return s.runOnErrorTask(context, failed.process, tasks, failed.err)
+	}
+	if err == nil && process.CanRun() {
+		if blocked := graph.blocked(); len(blocked) > 0 {
+			err = fmt.Errorf("unable to run tasks: %v, unresolved dependencies", blocked)
+		}
+	}
+	return err
+}
@@ -1,8 +1,12 @@
 package workflow
 
-import "github.com/viant/endly"
+import (
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
+)
 
 func init() {
+	msg.RegisterType(&LoadedEvent{}, &InitEvent{}, &EndEvent{}, &AsyncEvent{}, &ResumeEvent{}, &AttemptEvent{}, &MatrixCellEvent{}, &MatrixCellEndEvent{}, &PlanEvent{})
 	endly.Registry.Register(func() endly.Service {
 		return New()
 	})
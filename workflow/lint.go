@@ -0,0 +1,389 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	//SeverityError represents lint diagnostic that would fail workflow run
+	SeverityError = "error"
+	//SeverityWarning represents suspicious lint diagnostic
+	SeverityWarning = "warning"
+)
+
+var variableReferenceExpr = regexp.MustCompile(`\$\{?([A-Za-z_]\w*)`)
+var variableNameExpr = regexp.MustCompile(`[A-Za-z_]\w*`)
+
+//runtimeStateKeys represents state keys published by workflow service while running
+var runtimeStateKeys = []string{dataStateKey, paramsStateKey, tasksStateKey, selfStateKey, "index", "error", "errorJSON"}
+
+//Diagnostic represents workflow lint finding
+type Diagnostic struct {
+	Severity string `description:"error or warning"`
+	Code     string `description:"diagnostic code, i.e. unknown-service, unknown-action, unknown-field, unknown-task, undefined-variable, unused-variable"`
+	Message  string
+	URL      string `description:"workflow source URL"`
+	Line     int    `description:"source line, 0 if position could not be determined"`
+	Task     string
+	TagID    string
+}
+
+//lintPosition represents workflow node location
+type lintPosition struct {
+	line  int
+	task  string
+	tagID string
+}
+
+//variableReference represents variable reference found in workflow node
+type variableReference struct {
+	name string
+	*lintPosition
+}
+
+//linter represents workflow static validator
+type linter struct {
+	context     *endly.Context
+	workflow    *model.Workflow
+	source      *sourceLocator
+	defined     map[string]bool
+	declared    map[string]*lintPosition
+	referenced  map[string]bool
+	references  []*variableReference
+	diagnostics []*Diagnostic
+}
+
+//Lint statically validates workflow services, actions, request fields, goto/switch task targets and variables
+func Lint(context *endly.Context, workflow *model.Workflow, params map[string]interface{}) []*Diagnostic {
+	var l = &linter{
+		context:     context,
+		workflow:    workflow,
+		source:      newSourceLocator(workflow.Source),
+		defined:     make(map[string]bool),
+		declared:    make(map[string]*lintPosition),
+		referenced:  make(map[string]bool),
+		diagnostics: make([]*Diagnostic, 0),
+	}
+	for _, key := range runtimeStateKeys {
+		l.defined[key] = true
+	}
+	for key := range params {
+		l.defined[key] = true
+	}
+	for _, parameter := range workflow.Parameters {
+		l.defined[parameter.Name] = true
+	}
+	if workflow.AbstractNode != nil {
+		l.defined[workflow.Name] = true
+		var position = &lintPosition{line: l.source.Line(workflow.Name, 0)}
+		l.lintNode(workflow.AbstractNode, position)
+	}
+	l.lintTasks(workflow.TasksNode, 0)
+	l.lintVariables()
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		return l.diagnostics[i].Line < l.diagnostics[j].Line
+	})
+	return l.diagnostics
+}
+
+func (l *linter) report(severity, code string, position *lintPosition, template string, args ...interface{}) {
+	var diagnostic = &Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(template, args...),
+		Line:     position.line,
+		Task:     position.task,
+		TagID:    position.tagID,
+	}
+	if l.workflow.Source != nil {
+		diagnostic.URL = l.workflow.Source.URL
+	}
+	l.diagnostics = append(l.diagnostics, diagnostic)
+}
+
+func (l *linter) lintTasks(tasks *model.TasksNode, fromLine int) {
+	if tasks == nil {
+		return
+	}
+	for _, task := range tasks.Tasks {
+		var position = &lintPosition{line: l.source.Line(task.Name, fromLine), task: task.Name}
+		if task.AbstractNode != nil {
+			l.lintNode(task.AbstractNode, position)
+		}
+		for _, action := range task.Actions {
+			l.lintAction(task, action, position.line)
+		}
+		l.lintTasks(task.TasksNode, position.line)
+	}
+}
+
+//lintNode collects node variables, post variables are not checked for usage as they publish node output
+func (l *linter) lintNode(node *model.AbstractNode, position *lintPosition) {
+	for _, variable := range node.Init {
+		l.defineVariable(variable, position, true)
+		l.collectReferences(variable.Value, position, true)
+		l.collectReferences(variable.When, position, true)
+		l.collectReferences(variable.Else, position, true)
+	}
+	l.collectReferences(node.When, position, true)
+	for _, variable := range node.Post {
+		l.defineVariable(variable, position, false)
+		//post variables are evaluated with node output, thus only usage is recorded
+		l.collectReferences(variable.Value, position, false)
+		l.collectReferences(variable.When, position, false)
+		l.collectReferences(variable.Else, position, false)
+	}
+}
+
+func (l *linter) lintAction(task *model.Task, action *model.Action, fromLine int) {
+	var position = &lintPosition{line: fromLine, task: task.Name}
+	if action.MetaTag != nil {
+		position.tagID = action.TagID
+	}
+	if action.AbstractNode != nil {
+		if line := l.source.Line(action.Name, fromLine); line > 0 && action.Name != "" {
+			position.line = line
+		}
+		l.defined[action.Name] = true
+		l.lintNode(action.AbstractNode, position)
+	}
+	if action.ServiceRequest == nil {
+		return
+	}
+	if line := l.source.Line(action.Service+":"+action.Action, position.line); line > 0 {
+		position.line = line
+	} else if line := l.source.Line(action.Action, position.line); line > 0 {
+		position.line = line
+	}
+	l.defined[action.Action] = true
+	l.collectReferences(action.Skip, position, true)
+	if action.Repeater != nil {
+		l.collectReferences(action.Exit, position, false)
+		for _, extract := range action.Extract {
+			l.defined[variableName(extract.Key)] = true
+		}
+		for _, variable := range action.Variables {
+			l.defineVariable(variable, position, false)
+		}
+	}
+	if action.Retry != nil {
+		l.collectReferences(action.Retry.When, position, false)
+	}
+	l.collectReferences(action.Request, position, true)
+	l.lintRequest(action, position)
+}
+
+//lintRequest checks action service, route, request fields and task targets
+func (l *linter) lintRequest(action *model.Action, position *lintPosition) {
+	if strings.Contains(action.Service, "$") || strings.Contains(action.Action, "$") {
+		return
+	}
+	service, err := l.context.Service(action.Service)
+	if err != nil {
+		l.report(SeverityError, "unknown-service", position, "unknown service: %v", action.Service)
+		return
+	}
+	route, err := service.Route(action.Action)
+	if err != nil {
+		l.report(SeverityError, "unknown-action", position, "unknown %v action: %v, available: [%v]", action.Service, action.Action, strings.Join(service.Actions(), ","))
+		return
+	}
+	if action.Request == nil || !toolbox.IsMap(action.Request) {
+		return
+	}
+	request := toolbox.AsMap(action.Request)
+	if route.OnRawRequest == nil {
+		l.lintRequestFields(action, route, request, position)
+	}
+	if action.Service != ServiceID {
+		return
+	}
+	switch action.Action {
+	case "goto":
+		l.lintTaskTarget(getLintValue(request, "task"), position)
+	case "switch":
+		if cases := getLintValue(request, "cases"); cases != nil && toolbox.IsSlice(cases) {
+			for _, item := range toolbox.AsSlice(cases) {
+				if toolbox.IsMap(item) {
+					l.lintTaskTarget(getLintValue(toolbox.AsMap(item), "task"), position)
+				}
+			}
+		}
+		if defaultCase := getLintValue(request, "default"); defaultCase != nil && toolbox.IsMap(defaultCase) {
+			l.lintTaskTarget(getLintValue(toolbox.AsMap(defaultCase), "task"), position)
+		}
+	}
+}
+
+func (l *linter) lintRequestFields(action *model.Action, route *endly.Route, request map[string]interface{}, position *lintPosition) {
+	requestType := route.RequestProvider()
+	if !toolbox.IsStruct(requestType) {
+		return
+	}
+	var fields = toolbox.NewFieldSettingByKey(requestType, toolbox.DefaultConverter.MappedKeyTag)
+	for key := range request {
+		if strings.ContainsAny(key, "$@") {
+			continue
+		}
+		if _, ok := fields[strings.ToLower(key)]; ok {
+			continue
+		}
+		var fieldPosition = *position
+		if line := l.source.Line(key, position.line); line > 0 {
+			fieldPosition.line = line
+		}
+		l.report(SeverityError, "unknown-field", &fieldPosition, "unknown %v:%v request field: %v (%T)", action.Service, action.Action, key, requestType)
+	}
+}
+
+func (l *linter) lintTaskTarget(target interface{}, position *lintPosition) {
+	if target == nil {
+		return
+	}
+	task := toolbox.AsString(target)
+	if task == "" || strings.Contains(task, "$") {
+		return
+	}
+	if l.workflow.TasksNode == nil || !l.workflow.Has(task) {
+		l.report(SeverityError, "unknown-task", position, "unknown task: %v", task)
+	}
+}
+
+func (l *linter) defineVariable(variable *model.Variable, position *lintPosition, checkUsage bool) {
+	name := variableName(variable.Name)
+	if name == "" {
+		return
+	}
+	l.defined[name] = true
+	if checkUsage {
+		if _, ok := l.declared[name]; !ok {
+			l.declared[name] = position
+		}
+	}
+	if from := variableName(variable.From); from != "" {
+		l.referenced[from] = true
+	}
+}
+
+//collectReferences records $variable references, if resolve is set referenced variable has to be defined
+func (l *linter) collectReferences(source interface{}, position *lintPosition, resolve bool) {
+	if source == nil {
+		return
+	}
+	switch value := source.(type) {
+	case string:
+		for _, match := range variableReferenceExpr.FindAllStringSubmatch(value, -1) {
+			name := match[1]
+			l.referenced[name] = true
+			if resolve {
+				l.references = append(l.references, &variableReference{name: name, lintPosition: position})
+			}
+		}
+		return
+	}
+	if toolbox.IsMap(source) {
+		for key, value := range toolbox.AsMap(source) {
+			l.collectReferences(key, position, resolve)
+			l.collectReferences(value, position, resolve)
+		}
+	} else if toolbox.IsSlice(source) {
+		for _, item := range toolbox.AsSlice(source) {
+			l.collectReferences(item, position, resolve)
+		}
+	}
+}
+
+//lintVariables reports references to undefined variables and declared variables that are never used
+func (l *linter) lintVariables() {
+	var state = l.context.State()
+	var reported = make(map[string]bool)
+	for _, reference := range l.references {
+		if l.defined[reference.name] || state.Has(reference.name) || reported[reference.name] {
+			continue
+		}
+		reported[reference.name] = true
+		var position = *reference.lintPosition
+		if line := l.source.Line("$"+reference.name, position.line); line > 0 {
+			position.line = line
+		}
+		l.report(SeverityWarning, "undefined-variable", &position, "undefined variable: $%v", reference.name)
+	}
+	var names = toolbox.MapKeysToStringSlice(l.declared)
+	sort.Strings(names)
+	for _, name := range names {
+		if l.referenced[name] {
+			continue
+		}
+		var position = *l.declared[name]
+		if line := l.source.Line(name, position.line); line > 0 {
+			position.line = line
+		}
+		l.report(SeverityWarning, "unused-variable", &position, "unused variable: %v", name)
+	}
+}
+
+//variableName returns root state key for supplied variable name or expression, i.e. ->items returns items
+func variableName(name string) string {
+	return variableNameExpr.FindString(name)
+}
+
+func getLintValue(source map[string]interface{}, key string) interface{} {
+	for candidate, value := range source {
+		if strings.EqualFold(candidate, key) {
+			return value
+		}
+	}
+	return nil
+}
+
+//sourceLocator represents workflow source line lookup
+type sourceLocator struct {
+	lines []string
+}
+
+//Line returns first line number at or after fromLine with a key, a cell or a value matching supplied text, it wraps to the beginning, 0 if not found
+func (l *sourceLocator) Line(text string, fromLine int) int {
+	if text == "" || len(l.lines) == 0 {
+		return 0
+	}
+	quoted := regexp.QuoteMeta(text)
+	expr, err := regexp.Compile(`(^\s*(-\s*)?['"]?` + quoted + `['"]?\s*:)|((^|,)\s*` + quoted + `\s*(,|$))|(:\s*['"]?` + quoted + `['"]?\s*$)`)
+	if err != nil {
+		return 0
+	}
+	if fromLine < 1 {
+		fromLine = 1
+	}
+	for i := 0; i < len(l.lines); i++ {
+		index := (fromLine - 1 + i) % len(l.lines)
+		if expr.MatchString(l.lines[index]) {
+			return index + 1
+		}
+	}
+	if strings.HasPrefix(text, "$") {
+		for i := fromLine - 1; i < len(l.lines); i++ {
+			if strings.Contains(l.lines[i], text) {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+func newSourceLocator(resource *url.Resource) *sourceLocator {
+	var result = &sourceLocator{}
+	if resource == nil {
+		return result
+	}
+	if text, err := resource.DownloadText(); err == nil {
+		result.lines = strings.Split(text, "\n")
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//MatrixCell represents a single combination of matrix parameters
+type MatrixCell struct {
+	ID     string
+	Params map[string]interface{}
+}
+
+//MatrixCellResponse represents matrix cell run response
+type MatrixCellResponse struct {
+	ID     string
+	Params map[string]interface{}
+	Data   map[string]interface{}
+	Error  string
+}
+
+//MatrixCells expands supplied matrix into cartesian product of parameters, keys are sorted to produce stable cell order
+func MatrixCells(matrix map[string][]interface{}) []*MatrixCell {
+	if len(matrix) == 0 {
+		return nil
+	}
+	var keys = make([]string, 0, len(matrix))
+	for key := range matrix {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var result = []*MatrixCell{{Params: map[string]interface{}{}}}
+	for _, key := range keys {
+		var expanded = make([]*MatrixCell, 0, len(result)*len(matrix[key]))
+		for _, cell := range result {
+			for _, value := range matrix[key] {
+				var params = make(map[string]interface{}, len(cell.Params)+1)
+				for k, v := range cell.Params {
+					params[k] = v
+				}
+				params[key] = value
+				expanded = append(expanded, &MatrixCell{Params: params})
+			}
+		}
+		result = expanded
+	}
+	for _, cell := range result {
+		var pairs = make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, fmt.Sprintf("%v=%v", key, cell.Params[key]))
+		}
+		cell.ID = strings.Join(pairs, ",")
+	}
+	return result
+}
+
+//cellRequest returns a request to run supplied matrix cell
+func (r *RunRequest) cellRequest(cell *MatrixCell) *RunRequest {
+	request := *r
+	request.Matrix = nil
+	request.Async = false
+	request.Params = make(map[string]interface{}, len(r.Params)+len(cell.Params))
+	for k, v := range r.Params {
+		request.Params[k] = v
+	}
+	for k, v := range cell.Params {
+		request.Params[k] = v
+	}
+	return &request
+}
+
+//runMatrixCell runs workflow for supplied matrix cell in an isolated context
+func (s *Service) runMatrixCell(context *endly.Context, request *RunRequest, cell *MatrixCell) *MatrixCellResponse {
+	var result = &MatrixCellResponse{
+		ID:     cell.ID,
+		Params: cell.Params,
+	}
+	var processes = model.NewProcesses()
+	if upstream := Last(context); upstream != nil {
+		processes.Push(upstream)
+	}
+	_ = context.Replace(processesKey, processes)
+	context.Publish(NewMatrixCellEvent(cell))
+	response, err := s.runWorkflow(context, request.cellRequest(cell))
+	if response != nil {
+		result.Data = response.Data
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	context.Publish(NewMatrixCellEndEvent(result))
+	return result
+}
+
+//matrixListener forwards concurrent matrix cells events one at a time, when events of another cell were published in between, cell event is republished so that subsequent events can be attributed to the cell
+type matrixListener struct {
+	listener msg.Listener
+	cell     *MatrixCell
+	mux      *sync.Mutex
+}
+
+//cellListener returns listener publishing supplied cell events
+func (l *matrixListener) cellListener(cell *MatrixCell) msg.Listener {
+	if l.listener == nil {
+		return nil
+	}
+	return func(event msg.Event) {
+		l.mux.Lock()
+		defer l.mux.Unlock()
+		if l.cell != cell {
+			l.cell = cell
+			if _, ok := event.Value().(*MatrixCellEvent); !ok {
+				l.listener(msg.NewEvent(NewMatrixCellEvent(cell)))
+			}
+		}
+		l.listener(event)
+	}
+}
+
+func newMatrixListener(listener msg.Listener) *matrixListener {
+	return &matrixListener{
+		listener: listener,
+		mux:      &sync.Mutex{},
+	}
+}
+
+//runMatrix runs workflow for each matrix cell, cells run concurrently up to MatrixParallelism
+func (s *Service) runMatrix(context *endly.Context, request *RunRequest) (*RunResponse, error) {
+	cells := MatrixCells(request.Matrix)
+	response := &RunResponse{
+		Data:      make(map[string]interface{}),
+		SessionID: context.SessionID,
+		Matrix:    make([]*MatrixCellResponse, len(cells)),
+	}
+	parallelism := request.MatrixParallelism
+	if parallelism <= 1 {
+		for i, cell := range cells {
+			response.Matrix[i] = s.runMatrixCell(context.Clone(), request, cell)
+		}
+	} else {
+		var listener = newMatrixListener(context.Listener)
+		var limiter = make(chan bool, parallelism)
+		var group = &sync.WaitGroup{}
+		group.Add(len(cells))
+		for i, cell := range cells {
+			cellContext := context.Clone()
+			cellContext.MakeAsyncSafe()
+			cellContext.SetListener(listener.cellListener(cell)) //publish cell progress as it happens instead of buffered events
+			limiter <- true
+			go func(i int, cell *MatrixCell) {
+				defer func() {
+					<-limiter
+					group.Done()
+				}()
+				response.Matrix[i] = s.runMatrixCell(cellContext, request, cell)
+			}(i, cell)
+		}
+		group.Wait()
+	}
+	var failed = make([]string, 0)
+	for _, cell := range response.Matrix {
+		if cell.Error != "" {
+			failed = append(failed, cell.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return response, fmt.Errorf("failed matrix cells: %v", strings.Join(failed, "; "))
+	}
+	return response, nil
+}
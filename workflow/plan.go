@@ -0,0 +1,62 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+)
+
+//PlanEvent represents dry run action plan event
+type PlanEvent struct {
+	Workflow string
+	Task     string
+	TagID    string
+	Service  string
+	Action   string
+	Request  interface{}
+}
+
+//NewPlanEvent creates a new dry run action plan event
+func NewPlanEvent(process *model.Process, activity *model.Activity, request interface{}) *PlanEvent {
+	var result = &PlanEvent{
+		TagID:   activity.TagID,
+		Service: activity.Service,
+		Action:  activity.Action,
+		Request: request,
+	}
+	if process.Workflow != nil {
+		result.Workflow = process.Workflow.Name
+	}
+	if process.Task != nil {
+		result.Task = process.Task.Name
+	}
+	return result
+}
+
+//Messages returns tag messages
+func (e *PlanEvent) Messages() []*msg.Message {
+	var header = fmt.Sprintf("%v.%v %v.%v", e.Workflow, e.Task, e.Service, e.Action)
+	var result = msg.NewMessage(msg.NewStyled(header, msg.MessageStyleGeneric), msg.NewStyled("plan", msg.MessageStyleGeneric))
+	if request, err := toolbox.AsIndentJSONText(e.Request); err == nil {
+		result.Items = append(result.Items, msg.NewStyled(request, msg.MessageStyleInput))
+	}
+	return []*msg.Message{result}
+}
+
+//planActionRequest initialises and validates expanded action request, then publishes it as a plan event instead of calling service
+func (s *Service) planActionRequest(context *endly.Context, process *model.Process, activity *model.Activity, request interface{}) error {
+	if initializer, ok := request.(endly.Initializer); ok {
+		if err := initializer.Init(); err != nil {
+			return fmt.Errorf("init %T failed: %v", request, err)
+		}
+	}
+	if validator, ok := request.(endly.Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("validation %T failed: %v", request, err)
+		}
+	}
+	context.Publish(NewPlanEvent(process, activity, request))
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
@@ -104,7 +105,7 @@ func (s *Service) runAction(context *endly.Context, action *model.Action, proces
 			resultKey = action.Action
 		}
 		if err != nil {
-			err = fmt.Errorf("%v: %v", action.TagID, err)
+			err = fmt.Errorf("%v: %w", action.TagID, err)
 		} else if len(response) > 0 {
 			state.Put(resultKey, response)
 			var variables = model.Variables{
@@ -118,7 +119,14 @@ func (s *Service) runAction(context *endly.Context, action *model.Action, proces
 		}
 	}()
 	var request interface{}
+	endSpan := startActionSpan(context, activity)
+	defer func() {
+		endSpan(err)
+	}()
 	err = s.runNode(context, "action", process, action.AbstractNode, func(context *endly.Context, process *model.Process) (in, out data.Map, err error) {
+		if err = s.debug(context, process, action, activity); err != nil {
+			return nil, nil, err
+		}
 		process.Push(activity)
 		startEvent := s.Begin(context, activity)
 		defer s.End(context)(startEvent, model.NewActivityEndEvent(activity))
@@ -131,7 +139,10 @@ func (s *Service) runAction(context *endly.Context, action *model.Action, proces
 		}); err != nil {
 			return nil, nil, err
 		}
-		err = endly.Run(context, request, activity.ServiceResponse)
+		if _, isRunRequest := request.(*RunRequest); process.DryRun && !isRunRequest {
+			return data.NewMap(), state, s.planActionRequest(context, process, activity, request)
+		}
+		err = s.runActionRequest(context, action, activity, request)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -146,6 +157,39 @@ func (s *Service) runAction(context *endly.Context, action *model.Action, proces
 	return response, err
 }
 
+//runActionRequest runs action request, failed attempts are retried with backoff if action defines retry policy
+func (s *Service) runActionRequest(context *endly.Context, action *model.Action, activity *model.Activity, request interface{}) error {
+	retry := action.Retry
+	if retry == nil {
+		return endly.Run(context, request, activity.ServiceResponse)
+	}
+	started := time.Now()
+	for attempt := 1; ; attempt++ {
+		activity.ServiceResponse = &endly.ServiceResponse{}
+		err := endly.Run(context, request, activity.ServiceResponse)
+		if err == nil {
+			if attempt > 1 {
+				context.Publish(NewAttemptEvent(action, attempt, nil, 0))
+			}
+			return nil
+		}
+		delay := retry.Delay(attempt)
+		canRetry, retryErr := retry.CanRetry(context, attempt, time.Since(started)+delay, err)
+		if retryErr != nil {
+			return retryErr
+		}
+		if !canRetry || context.Background().Err() != nil {
+			context.Publish(NewAttemptEvent(action, attempt, err, 0))
+			return err
+		}
+		context.Publish(NewAttemptEvent(action, attempt, err, delay))
+		s.Sleep(context, int(delay/time.Millisecond))
+		if context.Background().Err() != nil { //cancelled while waiting for the next attempt
+			return err
+		}
+	}
+}
+
 func (s *Service) runTask(context *endly.Context, process *model.Process, task *model.Task) (data.Map, error) {
 	process.SetTask(task)
 	var result = data.NewMap()
@@ -155,6 +199,7 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 	var asyncError error
 	asyncActions := task.AsyncActions()
 
+	endSpan := startTaskSpan(context, task)
 	err := s.runNode(context, "task", process, task.AbstractNode, func(context *endly.Context, process *model.Process) (in, out data.Map, err error) {
 		if task.TasksNode != nil && len(task.Tasks) > 0 {
 			if err := s.runTasks(context, process, task.TasksNode); err != nil || len(task.Actions) == 0 {
@@ -194,11 +239,18 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 				}
 				continue
 			}
-			var extractable = make(map[string]interface{})
-			err = action.Repeater.Run(s.AbstractService, "action", context, handler(task.Actions[i]), extractable)
+			if process.DryRun {
+				_, err = handler(task.Actions[i])()
+			} else {
+				var extractable = make(map[string]interface{})
+				err = action.Repeater.Run(s.AbstractService, "action", context, handler(task.Actions[i]), extractable)
+			}
 			if err != nil {
 				return nil, nil, err
 			}
+			if process.Checkpoint != nil {
+				process.Checkpoint.AddAction(task.Name, action.TagID)
+			}
 		}
 
 		return state, result, nil
@@ -214,6 +266,7 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 			err = asyncError
 		}
 	}
+	endSpan(err)
 	state.Apply(result)
 	return result, err
 }
@@ -239,6 +292,10 @@ func (s *Service) runAsyncAction(parent, context *endly.Context, process *model.
 			return response, nil
 		}
 	}
+	if process.DryRun {
+		_, err := handler(action)()
+		return err
+	}
 	var extractable = make(map[string]interface{})
 	err := action.Repeater.Run(s.AbstractService, "action", context, handler(action), extractable)
 	if err != nil {
@@ -283,7 +340,7 @@ func (s *Service) run(context *endly.Context, request *RunRequest) (response *Ru
 		go func() {
 			defer context.Publish(NewEndEvent(context.SessionID))
 			defer context.Wait.Done()
-			_, err = s.runWorkflow(context, request)
+			_, err = s.runRequest(context, request)
 			if err != nil {
 				context.Publish(msg.NewErrorEvent(fmt.Sprintf("%v", err)))
 			}
@@ -291,6 +348,13 @@ func (s *Service) run(context *endly.Context, request *RunRequest) (response *Ru
 		return &RunResponse{}, nil
 	}
 	defer context.Publish(NewEndEvent(context.SessionID))
+	return s.runRequest(context, request)
+}
+
+func (s *Service) runRequest(context *endly.Context, request *RunRequest) (*RunResponse, error) {
+	if len(request.Matrix) > 0 {
+		return s.runMatrix(context, request)
+	}
 	return s.runWorkflow(context, request)
 }
 
@@ -302,16 +366,14 @@ func (s *Service) enableLoggingIfNeeded(context *endly.Context, request *RunRequ
 	}
 }
 
-func (s *Service) publishParameters(request *RunRequest, context *endly.Context) map[string]interface{} {
+func (s *Service) publishParameters(request *RunRequest, params data.Map, context *endly.Context) {
 	var state = context.State()
-	params := buildParamsMap(request, context)
 	if request.PublishParameters {
 		for key, value := range params {
 			state.Put(key, value)
 		}
 	}
 	state.Put(paramsStateKey, params)
-	return params
 }
 
 func (s *Service) getWorkflow(context *endly.Context, request *RunRequest) (*model.Workflow, error) {
@@ -342,11 +404,21 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	if err != nil {
 		return nil, err
 	}
+	params, err := buildWorkflowParams(upstreamContext, workflow, request)
+	if err != nil {
+		return nil, err
+	}
 
 	defer Pop(upstreamContext)
+	closeTracer, err := s.initTracer(upstreamContext, request)
+	if err != nil {
+		return nil, err
+	}
+	defer closeTracer()
 
 	upstreamProcess := Last(upstreamContext)
 	process := model.NewProcess(workflow.Source, workflow, upstreamProcess)
+	process.DryRun = process.DryRun || request.DryRun
 	process.AddTagIDs(strings.Split(request.TagIDs, ",")...)
 	Push(upstreamContext, process)
 
@@ -354,7 +426,7 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	upstreamState := upstreamContext.State()
 	if request.StateKey != "" {
 		if upstreamState.Has(request.StateKey) {
-			log.Print("detected workflow state key: %v is taken by: %v, skiping consider stateKey customiztion", request.StateKey, upstreamState.Get(request.StateKey))
+			log.Printf("detected workflow state key: %v is taken by: %v, skiping consider stateKey customiztion", request.StateKey, upstreamState.Get(request.StateKey))
 		}
 		upstreamState.Put(request.StateKey, process.State)
 		defer func() {
@@ -377,13 +449,16 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 		defer state.Put(selfStateKey, origSelfState)
 	}
 
-	params := s.publishParameters(request, context)
-	process.State.Put(paramsStateKey, params)
+	if err = s.initCheckpoint(context, request, workflow, process); err != nil {
+		return nil, err
+	}
 	if len(workflow.Data) > 0 {
 		state := context.State()
 		state.Put(dataStateKey, workflow.Data)
 		process.State.Put(dataStateKey, workflow.Data)
 	}
+	s.publishParameters(request, params, context)
+	process.State.Put(paramsStateKey, params)
 
 	upstreamTasks, hasUpstreamTasks := state.GetValue(tasksStateKey)
 	restore := context.PublishAndRestore(toolbox.Pairs(
@@ -409,23 +484,63 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	}
 
 	filteredTasks := workflow.TasksNode.Select(taskSelector)
+	endSpan := startWorkflowSpan(context, workflow)
 	err = s.runNode(context, "workflow", process, workflow.AbstractNode, func(context *endly.Context, process *model.Process) (in, out data.Map, err error) {
 		err = s.runTasks(context, process, filteredTasks)
 		return state, response.Data, err
 	})
+	endSpan(err)
 
 	if len(response.Data) > 0 {
 		for k, v := range response.Data {
 			upstreamState.Put(k, v)
 		}
 	}
+	if err == nil && process.Checkpoint != nil {
+		err = RemoveCheckpoint(process.Checkpoint.URL)
+	}
 	return response, err
 }
 
+func (s *Service) initCheckpoint(context *endly.Context, request *RunRequest, workflow *model.Workflow, process *model.Process) error {
+	if request.CheckpointURL == "" || process.DryRun {
+		return nil
+	}
+	URL := context.Expand(request.CheckpointURL)
+	if request.Resume {
+		checkpoint, err := LoadCheckpoint(URL)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			if checkpoint.Workflow != workflow.Name {
+				return fmt.Errorf("checkpoint %v was created for workflow: %v, but had: %v", URL, checkpoint.Workflow, workflow.Name)
+			}
+			checkpoint.URL = URL
+			restoreCheckpoint(context, process, checkpoint, request.StateKey)
+			context.Publish(NewResumeEvent(checkpoint))
+			return nil
+		}
+	}
+	process.Checkpoint = model.NewCheckpoint(URL, context.SessionID, workflow.Name)
+	return nil
+}
+
+func (s *Service) saveCheckpoint(context *endly.Context, process *model.Process, task *model.Task) error {
+	if process.Checkpoint == nil {
+		return nil
+	}
+	process.Checkpoint.AddTask(task.Name)
+	return SaveCheckpoint(process.Checkpoint.URL, context, process)
+}
+
 func (s *Service) runNode(context *endly.Context, nodeType string, process *model.Process, node *model.AbstractNode, runHandler func(context *endly.Context, process *model.Process) (in, out data.Map, err error)) error {
 	if !process.CanRun() {
 		return nil
 	}
+	if err := context.Background().Err(); err != nil {
+		return err
+	}
 	original := context.Logging
 	context.Logging = node.Logging
 	defer func() {
@@ -441,7 +556,7 @@ func (s *Service) runNode(context *endly.Context, nodeType string, process *mode
 	if err != nil {
 		return err
 	}
-	in, out, err := runHandler(context, process)
+	in, out, err := s.runNodeHandler(context, nodeType, process, node, runHandler)
 	if err != nil {
 		return err
 	}
@@ -453,10 +568,29 @@ func (s *Service) runNode(context *endly.Context, nodeType string, process *mode
 	if err != nil {
 		return err
 	}
-	s.Sleep(context, node.SleepTimeMs)
+	if !process.DryRun {
+		s.Sleep(context, node.SleepTimeMs)
+	}
 	return nil
 }
 
+//runNodeHandler runs node handler, if node defines timeout, context background is cancelled once it elapses,
+//so that nested nodes and services honouring context stop, in that case node fails with endly.TimeoutError.
+func (s *Service) runNodeHandler(context *endly.Context, nodeType string, process *model.Process, node *model.AbstractNode, runHandler func(context *endly.Context, process *model.Process) (in, out data.Map, err error)) (in, out data.Map, err error) {
+	if node.TimeoutMs <= 0 {
+		return runHandler(context, process)
+	}
+	timeout := time.Duration(node.TimeoutMs) * time.Millisecond
+	restore := context.WithTimeout(timeout)
+	defer restore()
+	deadline := context.Background()
+	in, out, err = runHandler(context, process)
+	if endly.IsTimeoutError(deadline.Err()) { //handler ignoring cancellation still fails once deadline passed
+		return nil, nil, endly.NewTimeoutError(fmt.Sprintf("%v %v", nodeType, node.Name), timeout)
+	}
+	return in, out, err
+}
+
 func (s *Service) runDeferredTask(context *endly.Context, process *model.Process, parent *model.TasksNode) error {
 	if parent.DeferredTask == "" {
 		return nil
@@ -472,6 +606,7 @@ func (s *Service) runOnErrorTask(context *endly.Context, process *model.Process,
 	}
 	if err != nil {
 		process.Error = err.Error()
+		process.Timeout = endly.IsTimeoutError(err)
 		if process.Activity != nil {
 			process.Request = process.Activity.Request
 			process.Response = process.Activity.Response
@@ -505,6 +640,23 @@ func (s *Service) runTasks(context *endly.Context, process *model.Process, tasks
 			err = e
 		}
 	}()
+	if tasks.HasDependencies() {
+		err = s.runTasksGraph(context, process, tasks)
+	} else {
+		err = s.runTasksInOrder(context, process, tasks)
+	}
+	if err != nil {
+		return err
+	}
+	var scheduledTask = process.Scheduled
+	if scheduledTask != nil {
+		process.Scheduled = nil
+		err = s.runTasks(context, process, &model.TasksNode{Tasks: []*model.Task{scheduledTask}})
+	}
+	return err
+}
+
+func (s *Service) runTasksInOrder(context *endly.Context, process *model.Process, tasks *model.TasksNode) (err error) {
 	for _, task := range tasks.Tasks {
 		if task.Name == tasks.OnErrorTask || task.Name == tasks.DeferredTask {
 			continue
@@ -512,24 +664,23 @@ func (s *Service) runTasks(context *endly.Context, process *model.Process, tasks
 		if process.IsTerminated() {
 			break
 		}
+		if process.Checkpoint.IsCompleted(task.Name) {
+			continue
+		}
 		if _, err = s.runTask(context, process, task); err != nil {
 			err = s.runOnErrorTask(context, process, tasks, err)
+		} else {
+			err = s.saveCheckpoint(context, process, task)
 		}
 		if err != nil {
 			return err
 		}
 	}
-	var scheduledTask = process.Scheduled
-	if scheduledTask != nil {
-		process.Scheduled = nil
-		err = s.runTasks(context, process, &model.TasksNode{Tasks: []*model.Task{scheduledTask}})
-	}
-	return err
+	return nil
 }
 
-func buildParamsMap(request *RunRequest, context *endly.Context) data.Map {
+func buildParamsMap(request *RunRequest, state data.Map) data.Map {
 	var params = data.NewMap()
-	var state = context.State()
 	if len(request.Params) > 0 {
 		for k, v := range request.Params {
 			params[k] = state.Expand(v)
@@ -538,6 +689,23 @@ func buildParamsMap(request *RunRequest, context *endly.Context) data.Map {
 	return params
 }
 
+//buildWorkflowParams expands request parameters and applies workflow parameters schema before the workflow process starts,
+//defaults can use workflow data but not init variables
+func buildWorkflowParams(context *endly.Context, workflow *model.Workflow, request *RunRequest) (data.Map, error) {
+	var state = data.NewMap()
+	for key, value := range context.State() {
+		state[key] = value
+	}
+	if len(workflow.Data) > 0 {
+		state.Put(dataStateKey, workflow.Data)
+	}
+	params := buildParamsMap(request, state)
+	if err := workflow.Parameters.Apply(params, state); err != nil {
+		return nil, fmt.Errorf("invalid workflow %v parameters: %v", workflow.Name, err)
+	}
+	return params, nil
+}
+
 func (s *Service) loadWorkflow(context *endly.Context, request *LoadRequest) (*LoadResponse, error) {
 	workflow, err := s.Dao.Load(context, request.Source)
 	if err != nil {
@@ -554,6 +722,24 @@ func (s *Service) loadWorkflow(context *endly.Context, request *LoadRequest) (*L
 	}, nil
 }
 
+func (s *Service) lint(context *endly.Context, request *LintRequest) (*LintResponse, error) {
+	workflow, err := s.getWorkflow(context, request.RunRequest)
+	if err != nil {
+		return nil, err
+	}
+	var response = &LintResponse{
+		Diagnostics: Lint(context, workflow, request.Params),
+	}
+	for _, diagnostic := range response.Diagnostics {
+		if diagnostic.Severity == SeverityError {
+			response.Errors++
+		} else {
+			response.Warnings++
+		}
+	}
+	return response, nil
+}
+
 func (s *Service) startSession(context *endly.Context) bool {
 	s.RLock()
 	var state = context.State()
@@ -785,6 +971,25 @@ func (s *Service) registerRoutes() {
 		},
 	})
 
+	s.AbstractService.Register(&endly.Route{
+		Action: "lint",
+		RequestInfo: &endly.ActionInfo{
+			Description: "statically validate workflow services, actions, request fields, task targets and variables",
+		},
+		RequestProvider: func() interface{} {
+			return &LintRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &LintResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*LintRequest); ok {
+				return s.lint(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+
 	s.AbstractService.Register(&endly.Route{
 		Action: "register",
 		RequestInfo: &endly.ActionInfo{
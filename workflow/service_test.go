@@ -1,14 +1,19 @@
 package workflow_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
 	"github.com/viant/endly/testing/endpoint/http"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/url"
@@ -247,10 +252,10 @@ func TestWorkflowService_OnErrorTask(t *testing.T) {
 func TestWorkflowService_RunHttpWorkflow(t *testing.T) {
 
 	baseDir := toolbox.CallerDirectory(3)
-	err := http.StartServer(8313, &http.HTTPServerTrips{
+	_, err := http.StartServer(8313, &http.HTTPServerTrips{
 		IndexKeys:     []string{http.MethodKey, http.URLKey, http.BodyKey, http.CookieKey, http.ContentTypeKey},
 		BaseDirectory: path.Join(baseDir, "test/endpoint"),
-	})
+	}, "bridge.HttpRequest-%v.json", "bridge.HttpResponse-%v.json")
 
 	if !assert.Nil(t, err) {
 		return
@@ -393,6 +398,315 @@ func TestWorkflowService_RunBroken(t *testing.T) {
 	}
 }
 
+func TestWorkflowService_RunWithCheckpoint(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/checkpoint/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	checkpointURL := "mem://localhost/checkpoint/workflow.json"
+	{
+		context := manager.NewContext(toolbox.NewContext())
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks:             "*",
+			Name:              "checkpoint",
+			Params:            map[string]interface{}{"fail": true, "marker": "first"},
+			PublishParameters: true,
+			CheckpointURL:     checkpointURL,
+		})
+		assert.True(t, strings.Contains(serviceResponse.Error, "task2 failed"), serviceResponse.Error)
+		checkpoint, err := workflow.LoadCheckpoint(checkpointURL)
+		if assert.Nil(t, err) && assert.NotNil(t, checkpoint) {
+			assert.EqualValues(t, []string{"task1"}, checkpoint.Tasks)
+			assert.EqualValues(t, "first", checkpoint.State["visited1"])
+		}
+	}
+	{
+		context := manager.NewContext(toolbox.NewContext())
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks:             "*",
+			Name:              "checkpoint",
+			Params:            map[string]interface{}{"fail": false, "marker": "second"},
+			PublishParameters: true,
+			CheckpointURL:     checkpointURL,
+			Resume:            true,
+		})
+		assert.EqualValues(t, "", serviceResponse.Error)
+		response, ok := serviceResponse.Response.(*workflow.RunResponse)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, "first", response.Data["visited1"])
+			assert.EqualValues(t, "second", response.Data["visited3"])
+		}
+		checkpoint, err := workflow.LoadCheckpoint(checkpointURL)
+		assert.Nil(t, err)
+		assert.Nil(t, checkpoint)
+	}
+}
+
+func TestWorkflowService_RunWithDependencies(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/graph/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks:             "*",
+		Name:              "graph",
+		PublishParameters: true,
+	})
+	assert.EqualValues(t, "", serviceResponse.Error)
+	response, ok := serviceResponse.Response.(*workflow.RunResponse)
+	if assert.True(t, ok) {
+		assert.EqualValues(t, "a-b", response.Data["visitedB"])
+		assert.EqualValues(t, "a-c", response.Data["visitedC"])
+		assert.EqualValues(t, "a-b-a-c", response.Data["visitedD"])
+	}
+}
+
+func TestWorkflowService_RunWithRetry(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/retry/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	var attempts = make([]*workflow.AttemptEvent, 0)
+	context.SetListener(func(event msg.Event) {
+		if attempt, ok := event.Value().(*workflow.AttemptEvent); ok {
+			attempts = append(attempts, attempt)
+		}
+	})
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks: "*",
+		Name:  "retry",
+	})
+	assert.True(t, strings.Contains(serviceResponse.Error, "transient error"), serviceResponse.Error)
+	if assert.EqualValues(t, 3, len(attempts)) {
+		for i, attempt := range attempts {
+			assert.EqualValues(t, i+1, attempt.Attempt)
+			assert.EqualValues(t, 3, attempt.MaxAttempts)
+			assert.True(t, strings.Contains(attempt.Error, "transient error"))
+		}
+		assert.EqualValues(t, 0, attempts[2].DelayMs)
+	}
+}
+
+func TestWorkflowService_RunWithCancelledRetry(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/retry/cancelled.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	var attempts = make([]*workflow.AttemptEvent, 0)
+	context.SetListener(func(event msg.Event) {
+		if attempt, ok := event.Value().(*workflow.AttemptEvent); ok {
+			attempts = append(attempts, attempt)
+		}
+	})
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks: "*",
+		Name:  "cancelled",
+	})
+	assert.True(t, strings.Contains(serviceResponse.Error, "timed out"), serviceResponse.Error)
+	assert.True(t, len(attempts) > 0 && len(attempts) <= 3, len(attempts))
+	for _, attempt := range attempts {
+		assert.True(t, strings.Contains(attempt.Error, "transient error"))
+	}
+}
+
+func TestWorkflowService_RunWithTimeout(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/timeout/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks: "*",
+		Name:  "timeout",
+	})
+	assert.EqualValues(t, "", serviceResponse.Error)
+	response, ok := serviceResponse.Response.(*workflow.RunResponse)
+	if assert.True(t, ok) {
+		assert.EqualValues(t, true, response.Data["timeoutCaught"])
+		assert.True(t, strings.Contains(toolbox.AsString(response.Data["errorCaught"]), "task slow timed out after 100ms"), response.Data["errorCaught"])
+	}
+}
+
+//sleepRequest represents test sleeper request
+type sleepRequest struct {
+	SleepMs int
+}
+
+//newSleeperService creates test service which action ignores context cancellation
+func newSleeperService() endly.Service {
+	var result = &struct{ *endly.AbstractService }{endly.NewAbstractService("test/sleeper")}
+	result.AbstractService.Service = result
+	result.Register(&endly.Route{
+		Action: "sleep",
+		RequestProvider: func() interface{} {
+			return &sleepRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &struct{}{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			time.Sleep(time.Duration(request.(*sleepRequest).SleepMs) * time.Millisecond)
+			return &struct{}{}, nil
+		},
+	})
+	return result
+}
+
+func TestWorkflowService_RunWithIgnoredTimeout(t *testing.T) {
+	endly.Registry.Register(newSleeperService)
+	manager, service, err := getServiceWithWorkflow("test/timeout/ignored.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks: "*",
+		Name:  "ignored",
+	})
+	assert.EqualValues(t, "", serviceResponse.Error)
+	response, ok := serviceResponse.Response.(*workflow.RunResponse)
+	if assert.True(t, ok) {
+		assert.EqualValues(t, true, response.Data["timeoutCaught"])
+		assert.True(t, strings.Contains(toolbox.AsString(response.Data["errorCaught"]), "task slow timed out after 100ms"), response.Data["errorCaught"])
+	}
+}
+
+func TestMatrixCells(t *testing.T) {
+	cells := workflow.MatrixCells(map[string][]interface{}{
+		"version": {1, 2},
+		"db":      {"mysql", "pg"},
+	})
+	if assert.EqualValues(t, 4, len(cells)) {
+		assert.EqualValues(t, "db=mysql,version=1", cells[0].ID)
+		assert.EqualValues(t, "db=mysql,version=2", cells[1].ID)
+		assert.EqualValues(t, "db=pg,version=1", cells[2].ID)
+		assert.EqualValues(t, "db=pg,version=2", cells[3].ID)
+		assert.EqualValues(t, map[string]interface{}{"db": "pg", "version": 2}, cells[3].Params)
+	}
+	assert.Nil(t, workflow.MatrixCells(nil))
+}
+
+func TestWorkflowService_RunMatrix(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/matrix/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, parallelism := range []int{0, 4} {
+		context := manager.NewContext(toolbox.NewContext())
+		var started = make(map[string]bool)
+		var ended = 0
+		context.SetListener(func(event msg.Event) {
+			switch value := event.Value().(type) {
+			case *workflow.MatrixCellEvent:
+				started[value.ID] = true
+			case *workflow.MatrixCellEndEvent:
+				ended++
+			}
+		})
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks:             "*",
+			Name:              "matrix",
+			PublishParameters: true,
+			Matrix: map[string][]interface{}{
+				"db":      {"mysql", "pg"},
+				"version": {1, 2},
+			},
+			MatrixParallelism: parallelism,
+		})
+		assert.True(t, strings.Contains(serviceResponse.Error, "failed matrix cells: db=pg,version=1"), serviceResponse.Error)
+		response, ok := serviceResponse.Response.(*workflow.RunResponse)
+		if !assert.True(t, ok) || !assert.EqualValues(t, 4, len(response.Matrix)) {
+			continue
+		}
+		assert.EqualValues(t, "mysql-1", response.Matrix[0].Data["cell"])
+		assert.EqualValues(t, "mysql-2", response.Matrix[1].Data["cell"])
+		assert.True(t, strings.Contains(response.Matrix[2].Error, "unsupported cell"), response.Matrix[2].Error)
+		assert.EqualValues(t, "pg-2", response.Matrix[3].Data["cell"])
+		assert.EqualValues(t, 4, len(started))
+		assert.EqualValues(t, 4, ended)
+	}
+}
+
+func TestRunRequest_ValidateMatrix(t *testing.T) {
+	var useCases = []struct {
+		description string
+		matrix      map[string][]interface{}
+		expectError string
+	}{
+		{
+			description: "valid matrix",
+			matrix:      map[string][]interface{}{"db": {"mysql", "pg"}},
+		},
+		{
+			description: "empty dimension",
+			matrix:      map[string][]interface{}{"db": {"mysql"}, "version": {}},
+			expectError: "matrix.version was empty",
+		},
+		{
+			description: "empty dimension name",
+			matrix:      map[string][]interface{}{"": {1}},
+			expectError: "matrix parameter name was empty",
+		},
+	}
+	for _, useCase := range useCases {
+		request := &workflow.RunRequest{Name: "matrix", URL: "test/matrix/workflow.csv", Matrix: useCase.matrix}
+		err := request.Validate()
+		if useCase.expectError == "" {
+			assert.Nil(t, err, useCase.description)
+			continue
+		}
+		if assert.NotNil(t, err, useCase.description) {
+			assert.EqualValues(t, useCase.expectError, err.Error(), useCase.description)
+		}
+	}
+}
+
+func TestWorkflowService_RunDryRun(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/dryrun/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	{
+		context := manager.NewContext(toolbox.NewContext())
+		var plan = make([]*workflow.PlanEvent, 0)
+		context.SetListener(func(event msg.Event) {
+			if planned, ok := event.Value().(*workflow.PlanEvent); ok {
+				plan = append(plan, planned)
+			}
+		})
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks:             "*",
+			Name:              "dryrun",
+			Params:            map[string]interface{}{"env": "prod", "invalid": false},
+			PublishParameters: true,
+			DryRun:            true,
+		})
+		assert.EqualValues(t, "", serviceResponse.Error)
+		if assert.EqualValues(t, 1, len(plan)) {
+			assert.EqualValues(t, "deploy", plan[0].Task)
+			assert.EqualValues(t, "workflow.fail", plan[0].Service+"."+plan[0].Action)
+			request, ok := plan[0].Request.(*workflow.FailRequest)
+			if assert.True(t, ok) {
+				assert.EqualValues(t, "dropping prod-cluster", request.Message)
+			}
+		}
+	}
+	{
+		context := manager.NewContext(toolbox.NewContext())
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks:             "*",
+			Name:              "dryrun",
+			Params:            map[string]interface{}{"env": "prod", "invalid": true},
+			PublishParameters: true,
+			DryRun:            true,
+		})
+		assert.True(t, strings.Contains(serviceResponse.Error, "validation *workflow.SwitchRequest failed"), serviceResponse.Error)
+	}
+}
+
 func Test_WorkflowSwitchRequest_Validate(t *testing.T) {
 	{
 		request := &workflow.SwitchRequest{}
@@ -452,3 +766,289 @@ func Test_PrintRequest(t *testing.T) {
 		assert.Equal(t, msg, m[0].Items[0].Text)
 	})
 }
+
+func TestWorkflowService_Lint(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/lint/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	serviceResponse := service.Run(context, &workflow.LintRequest{
+		RunRequest: &workflow.RunRequest{
+			Name: "lint",
+		},
+	})
+	if !assert.EqualValues(t, "", serviceResponse.Error) {
+		return
+	}
+	response, ok := serviceResponse.Response.(*workflow.LintResponse)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.EqualValues(t, 3, response.Errors)
+	assert.EqualValues(t, 2, response.Warnings)
+	var expected = []struct {
+		Code string
+		Line int
+	}{
+		{"unused-variable", 5},
+		{"unknown-action", 8},
+		{"unknown-field", 9},
+		{"unknown-task", 10},
+		{"undefined-variable", 11},
+	}
+	if assert.EqualValues(t, len(expected), len(response.Diagnostics)) {
+		for i, diagnostic := range response.Diagnostics {
+			assert.EqualValues(t, expected[i].Code, diagnostic.Code, diagnostic.Message)
+			assert.EqualValues(t, expected[i].Line, diagnostic.Line, diagnostic.Message)
+			assert.EqualValues(t, "build", diagnostic.Task)
+		}
+	}
+}
+
+func TestWorkflowService_RunWithDebugger(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/debug/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	{
+		context := manager.NewContext(toolbox.NewContext())
+		var paused = make([]*workflow.DebugPause, 0)
+		debugger := workflow.NewDebugger(func(context *endly.Context, pause *workflow.DebugPause) string {
+			paused = append(paused, pause)
+			if len(paused) == 1 {
+				state := context.State()
+				state.SetValue("target", "prod")
+				return workflow.DebugStepOver
+			}
+			return workflow.DebugContinue
+		}, workflow.NewBreakpoint("build.package"))
+		workflow.SetDebugger(context, debugger)
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks: "*",
+			Name:  "debug",
+		})
+		if !assert.EqualValues(t, "", serviceResponse.Error) {
+			return
+		}
+		if assert.EqualValues(t, 2, len(paused)) {
+			assert.EqualValues(t, "package", paused[0].Name)
+			assert.EqualValues(t, "build.package", paused[0].Breakpoint)
+			assert.EqualValues(t, "packaging dev", toolbox.AsMap(paused[0].Request)["Message"])
+			assert.EqualValues(t, "publish", paused[1].Name)
+			assert.EqualValues(t, "", paused[1].Breakpoint)
+			assert.EqualValues(t, "publishing prod", toolbox.AsMap(paused[1].Request)["Message"])
+		}
+		response, ok := serviceResponse.Response.(*workflow.RunResponse)
+		if assert.True(t, ok) {
+			assert.EqualValues(t, "prod", response.Data["result"])
+		}
+	}
+	{
+		context := manager.NewContext(toolbox.NewContext())
+		var paused = make([]string, 0)
+		workflow.SetDebugger(context, workflow.NewDebugger(func(context *endly.Context, pause *workflow.DebugPause) string {
+			paused = append(paused, pause.Name)
+			return workflow.DebugAbort
+		}))
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Tasks: "*",
+			Name:  "debug",
+		})
+		assert.True(t, strings.Contains(serviceResponse.Error, "aborted by debugger"), serviceResponse.Error)
+		assert.EqualValues(t, []string{"compile"}, paused)
+	}
+}
+
+func TestWorkflowService_RunWithTracing(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/tracing/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	traceFile := path.Join(os.TempDir(), "endly_tracing_test.jsonl")
+	_ = os.Remove(traceFile)
+	defer os.Remove(traceFile)
+	context := manager.NewContext(toolbox.NewContext())
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks:    "*",
+		Name:     "tracing",
+		TraceURL: traceFile,
+	})
+	assert.True(t, strings.Contains(serviceResponse.Error, "upload failed"), serviceResponse.Error)
+
+	content, err := ioutil.ReadFile(traceFile)
+	if !assert.Nil(t, err) {
+		return
+	}
+	type span struct {
+		Name        string
+		SpanContext struct {
+			TraceID string
+			SpanID  string
+		}
+		Parent struct {
+			SpanID string
+		}
+		Status struct {
+			Code string
+		}
+	}
+	var spans = make(map[string]*span)
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		item := &span{}
+		if !assert.Nil(t, json.Unmarshal([]byte(line), item), line) {
+			return
+		}
+		spans[item.Name] = item
+	}
+	var expected = []struct {
+		name   string
+		parent string
+		status string
+	}{
+		{"workflow tracing", "", "Error"},
+		{"task build", "workflow tracing", "Unset"},
+		{"workflow:print", "task build", "Unset"},
+		{"task deploy", "workflow tracing", "Error"},
+		{"workflow:fail", "task deploy", "Error"},
+	}
+	if !assert.EqualValues(t, len(expected), len(spans)) {
+		return
+	}
+	for _, useCase := range expected {
+		actual, ok := spans[useCase.name]
+		if !assert.True(t, ok, useCase.name) {
+			continue
+		}
+		assert.EqualValues(t, useCase.status, actual.Status.Code, useCase.name)
+		assert.EqualValues(t, spans["workflow tracing"].SpanContext.TraceID, actual.SpanContext.TraceID, useCase.name)
+		if useCase.parent != "" {
+			assert.EqualValues(t, spans[useCase.parent].SpanContext.SpanID, actual.Parent.SpanID, useCase.name)
+		}
+	}
+}
+
+func TestWorkflowService_RunWithParameters(t *testing.T) {
+	manager, service, err := getServiceWithWorkflow("test/params/workflow.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	loaded, err := service.(*workflow.Service).Workflow("params")
+	if !assert.Nil(t, err) || !assert.EqualValues(t, 3, len(loaded.Parameters)) {
+		return
+	}
+	assert.EqualValues(t, "env (string, required, enum: [dev qa]): target environment", loaded.Parameters[0].String())
+
+	var useCases = []struct {
+		description string
+		params      map[string]interface{}
+		expected    interface{}
+		error       string
+	}{
+		{
+			description: "default value",
+			params:      map[string]interface{}{"env": "dev"},
+			expected:    "dev:8080",
+		},
+		{
+			description: "converted value",
+			params:      map[string]interface{}{"env": "qa", "port": "9090", "verbose": "true"},
+			expected:    "qa:9090",
+		},
+		{
+			description: "aggregated errors",
+			params:      map[string]interface{}{"port": "abc", "verbose": "maybe"},
+			error:       "invalid workflow params parameters: env: was required; port: expected int, but had abc; verbose: expected bool, but had maybe",
+		},
+		{
+			description: "enum error",
+			params:      map[string]interface{}{"env": "prod"},
+			error:       "env: prod is not one of [dev qa]",
+		},
+	}
+	for _, useCase := range useCases {
+		context := manager.NewContext(toolbox.NewContext())
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Name:   "params",
+			Tasks:  "*",
+			Params: useCase.params,
+		})
+		if useCase.error != "" {
+			assert.True(t, strings.Contains(serviceResponse.Error, useCase.error), useCase.description+": "+serviceResponse.Error)
+			continue
+		}
+		if !assert.EqualValues(t, "", serviceResponse.Error, useCase.description) {
+			continue
+		}
+		response, ok := serviceResponse.Response.(*workflow.RunResponse)
+		if assert.True(t, ok, useCase.description) {
+			assert.EqualValues(t, useCase.expected, response.Data["result"], useCase.description)
+		}
+	}
+
+	{ //invalid parameters fail before checkpoint is resumed
+		checkpointURL := "mem://localhost/checkpoint/params.json"
+		context := manager.NewContext(toolbox.NewContext())
+		state := context.State()
+		state.Put("restored", true)
+		process := &model.Process{Checkpoint: model.NewCheckpoint(checkpointURL, context.SessionID, "params")}
+		if !assert.Nil(t, workflow.SaveCheckpoint(checkpointURL, context, process)) {
+			return
+		}
+		context = manager.NewContext(toolbox.NewContext())
+		var resumed = false
+		context.SetListener(func(event msg.Event) {
+			if _, ok := event.Value().(*workflow.ResumeEvent); ok {
+				resumed = true
+			}
+		})
+		serviceResponse := service.Run(context, &workflow.RunRequest{
+			Name:          "params",
+			Tasks:         "*",
+			Params:        map[string]interface{}{"env": "prod"},
+			SharedState:   true,
+			CheckpointURL: checkpointURL,
+			Resume:        true,
+		})
+		assert.True(t, strings.Contains(serviceResponse.Error, "env: prod is not one of [dev qa]"), serviceResponse.Error)
+		assert.False(t, resumed)
+		state = context.State()
+		assert.False(t, state.Has("restored"))
+		assert.Nil(t, workflow.Last(context))
+	}
+}
+
+func TestWorkflowService_RunWithDependenciesFailure(t *testing.T) {
+	endly.Registry.Register(newSleeperService)
+	manager, service, err := getServiceWithWorkflow("test/graph/failure.csv")
+	if !assert.Nil(t, err) {
+		return
+	}
+	context := manager.NewContext(toolbox.NewContext())
+	var started = time.Now()
+	var sleepStarted, recovered time.Duration
+	var recoverCount = 0
+	context.SetListener(func(event msg.Event) {
+		switch value := event.Value().(type) {
+		case *model.Activity:
+			if value.Service == "test/sleeper" {
+				sleepStarted = time.Since(started)
+			}
+		case *msg.ResetError:
+			recovered = time.Since(started)
+			recoverCount++
+		}
+	})
+	serviceResponse := service.Run(context, &workflow.RunRequest{
+		Tasks: "*",
+		Name:  "failure",
+	})
+	assert.EqualValues(t, "", serviceResponse.Error)
+	assert.EqualValues(t, 1, recoverCount)
+	assert.True(t, sleepStarted < 200*time.Millisecond, sleepStarted)
+	assert.True(t, recovered >= 300*time.Millisecond, recovered)
+	response, ok := serviceResponse.Response.(*workflow.RunResponse)
+	if assert.True(t, ok) {
+		assert.True(t, strings.Contains(toolbox.AsString(response.Data["errorCaught"]), "quick failed"), response.Data["errorCaught"])
+	}
+}
@@ -0,0 +1,203 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/assertly"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox/url"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	//TraceURLEnvKey represents env variable with default trace export URL
+	TraceURLEnvKey       = "ENDLY_TRACE_URL"
+	otlpEndpointEnvKey   = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otlpTracesEnvKey     = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	tracerName           = "github.com/viant/endly/workflow"
+	traceServiceName     = "endly"
+	traceAttributePrefix = "endly."
+)
+
+var tracerKey = (*tracer)(nil)
+
+//tracer represents workflow run tracer
+type tracer struct {
+	trace.Tracer
+	provider *sdktrace.TracerProvider
+}
+
+//assertable represents service response with validations
+type assertable interface {
+	Assertion() []*assertly.Validation
+}
+
+//fileExporter represents span exporter writing JSON lines to a file
+type fileExporter struct {
+	*stdouttrace.Exporter
+	file *os.File
+}
+
+//Shutdown flushes and closes exporter file
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if closeErr := e.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+//newSpanExporter creates OTLP/HTTP exporter for http(s) URL, JSON lines file exporter otherwise, with empty URL OTLP exporter is configured with OTEL_EXPORTER_OTLP_* env variables
+func newSpanExporter(URL string) (sdktrace.SpanExporter, error) {
+	if URL == "" {
+		return otlptracehttp.New(context.Background())
+	}
+	if strings.HasPrefix(URL, "http://") || strings.HasPrefix(URL, "https://") {
+		resource := url.NewResource(URL)
+		var options = []otlptracehttp.Option{otlptracehttp.WithEndpoint(resource.ParsedURL.Host)}
+		if resource.ParsedURL.Path != "" && resource.ParsedURL.Path != "/" {
+			options = append(options, otlptracehttp.WithURLPath(resource.ParsedURL.Path))
+		}
+		if resource.ParsedURL.Scheme == "http" {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), options...)
+	}
+	filename := url.NewResource(URL).ParsedURL.Path
+	if err := os.MkdirAll(path.Dir(filename), 0744); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &fileExporter{Exporter: exporter, file: file}, nil
+}
+
+//initTracer attaches tracer to the context if trace URL is configured and no tracer has been attached by a caller workflow, returned function flushes and detaches the tracer
+func (s *Service) initTracer(context *endly.Context, request *RunRequest) (func(), error) {
+	var noop = func() {}
+	if context.Contains(tracerKey) {
+		return noop, nil
+	}
+	URL := request.TraceURL
+	if URL == "" {
+		URL = os.Getenv(TraceURLEnvKey)
+	}
+	if URL == "" && os.Getenv(otlpEndpointEnvKey) == "" && os.Getenv(otlpTracesEnvKey) == "" {
+		return noop, nil
+	}
+	exporter, err := newSpanExporter(context.Expand(URL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %v, %v", URL, err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", traceServiceName))),
+	)
+	_ = context.Put(tracerKey, &tracer{Tracer: provider.Tracer(tracerName), provider: provider})
+	return func() {
+		shutdownTracer(provider)
+		context.Remove(tracerKey)
+	}, nil
+}
+
+//shutdownTracer flushes pending spans, it does not use run context as it may have been already cancelled
+func shutdownTracer(provider *sdktrace.TracerProvider) {
+	_ = provider.Shutdown(context.Background())
+}
+
+func getTracer(context *endly.Context) *tracer {
+	if !context.Contains(tracerKey) {
+		return nil
+	}
+	var result *tracer
+	context.GetInto(tracerKey, &result)
+	return result
+}
+
+//startSpan starts a child span of the context span, returned function ends the span with supplied error and attributes
+func startSpan(context *endly.Context, name string, attributes ...attribute.KeyValue) func(err error, attributes ...attribute.KeyValue) {
+	tracer := getTracer(context)
+	if tracer == nil {
+		return func(err error, attributes ...attribute.KeyValue) {}
+	}
+	background, span := tracer.Start(context.Background(), name, trace.WithAttributes(attributes...))
+	restore := context.WithBackground(background)
+	return func(err error, attributes ...attribute.KeyValue) {
+		restore()
+		span.SetAttributes(attributes...)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+//startWorkflowSpan starts workflow span, returned function ends it with supplied error
+func startWorkflowSpan(context *endly.Context, workflow *model.Workflow) func(err error) {
+	endSpan := startSpan(context, "workflow "+workflow.Name,
+		attribute.String(traceAttributePrefix+"workflow", workflow.Name),
+		attribute.String(traceAttributePrefix+"session_id", context.SessionID))
+	return func(err error) {
+		endSpan(err)
+	}
+}
+
+//startTaskSpan starts task span, returned function ends it with supplied error
+func startTaskSpan(context *endly.Context, task *model.Task) func(err error) {
+	endSpan := startSpan(context, "task "+task.Name, attribute.String(traceAttributePrefix+"task", task.Name))
+	return func(err error) {
+		endSpan(err)
+	}
+}
+
+//startActionSpan starts action span, returned function ends it with supplied error and activity assertion counts
+func startActionSpan(context *endly.Context, activity *model.Activity) func(err error) {
+	endSpan := startSpan(context, activity.Service+":"+activity.Action,
+		attribute.String(traceAttributePrefix+"service", activity.Service),
+		attribute.String(traceAttributePrefix+"action", activity.Action),
+		attribute.String(traceAttributePrefix+"tag_id", activity.TagID))
+	return func(err error) {
+		endSpan(err, assertionAttributes(activity)...)
+	}
+}
+
+//assertionAttributes returns passed and failed assertion count attributes for supplied activity response
+func assertionAttributes(activity *model.Activity) []attribute.KeyValue {
+	if activity == nil || activity.ServiceResponse == nil {
+		return nil
+	}
+	response, ok := activity.ServiceResponse.Response.(assertable)
+	if !ok {
+		return nil
+	}
+	var passed, failed = 0, 0
+	for _, validation := range response.Assertion() {
+		if validation == nil {
+			continue
+		}
+		passed += validation.PassedCount
+		failed += validation.FailedCount
+	}
+	return []attribute.KeyValue{
+		attribute.Int(traceAttributePrefix+"assert.passed", passed),
+		attribute.Int(traceAttributePrefix+"assert.failed", failed),
+	}
+}